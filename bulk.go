@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// GetBulkData returns the metadata for one of Scryfall's bulk files, by type
+// ("default_cards", "oracle_cards", "unique_artwork", "all_cards", ...)
+func (c *Client) GetBulkData(bulkType string) (*BulkData, error) {
+	var bulk BulkData
+	err := c.makeRequest("/bulk-data/"+url.PathEscape(bulkType), &bulk)
+	return &bulk, err
+}
+
+// EachCard streams every card in the bulkType bulk file to fn, skipping cards that
+// filter rejects (a nil filter keeps every card). The file is downloaded to CacheDir,
+// or the system temp directory without one, and is reused until Scryfall publishes
+// a newer one. Returning ErrStopSearch from fn stops early without error.
+func (c *Client) EachCard(bulkType string, filter func(Card) bool, fn func(Card) error) error {
+	path, err := c.bulkFile(bulkType)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	dec := json.NewDecoder(file)
+	if err := expectDelim(dec, '['); err != nil {
+		return fmt.Errorf("error reading %s bulk file: %v", bulkType, err)
+	}
+	for dec.More() {
+		var card Card
+		if err := dec.Decode(&card); err != nil {
+			return fmt.Errorf("error reading %s bulk file: %v", bulkType, err)
+		}
+		if filter != nil && !filter(card) {
+			continue
+		}
+		if err := fn(card); err != nil {
+			if errors.Is(err, ErrStopSearch) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// bulkFile returns the path of an up-to-date local copy of the bulkType bulk file,
+// downloading it first if there is none or Scryfall's is newer. The file's
+// modification time is set to the bulk data's updated_at to track freshness.
+func (c *Client) bulkFile(bulkType string) (string, error) {
+	bulk, err := c.GetBulkData(bulkType)
+	if err != nil {
+		return "", fmt.Errorf("error getting %s bulk data: %v", bulkType, err)
+	}
+
+	dir := c.cacheDir
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "scryfall-bulk")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return "", err
+		}
+	}
+	path := filepath.Join(dir, "bulk-"+bulkType+".json")
+
+	if info, err := os.Stat(path); err == nil && !info.ModTime().Before(bulk.UpdatedAt) {
+		return path, nil
+	}
+
+	tmp, err := os.CreateTemp(dir, "bulk-*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := c.download(bulk.DownloadURI, "*/*", tmp); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("error downloading %s bulk file: %v", bulkType, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chtimes(tmp.Name(), bulk.UpdatedAt, bulk.UpdatedAt); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// cacheEntry is a cached response body together with the ETag Scryfall sent for it
+type cacheEntry struct {
+	URL  string `json:"url"`
+	ETag string `json:"etag"`
+	Body []byte `json:"body"`
+}
+
+// cachePath returns the file a response for fullURL is cached in
+func (c *Client) cachePath(fullURL string) string {
+	sum := sha256.Sum256([]byte(fullURL))
+	return filepath.Join(c.cacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+// loadCache returns the cached response for fullURL, or nil when caching is off or there is none
+func (c *Client) loadCache(fullURL string) *cacheEntry {
+	if c.cacheDir == "" {
+		return nil
+	}
+	data, err := os.ReadFile(c.cachePath(fullURL))
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.URL != fullURL || entry.ETag == "" {
+		return nil
+	}
+	return &entry
+}
+
+// storeCache saves a response body and its ETag for fullURL.
+// The file is written to a temp name and renamed so readers never see a partial entry.
+func (c *Client) storeCache(fullURL, etag string, body []byte) error {
+	if c.cacheDir == "" || etag == "" {
+		return nil
+	}
+	data, err := json.Marshal(cacheEntry{URL: fullURL, ETag: etag, Body: body})
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.cacheDir, "entry-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.cachePath(fullURL))
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestETagCache(t *testing.T) {
+	var requests []string // method, path and If-None-Match of every request
+	server := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.Header.Get("If-None-Match"))
+		switch r.URL.Path {
+		case "/sets/isd":
+			w.Header().Set("ETag", `"v1"`)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Write([]byte(`{"object":"set","code":"isd","name":"Innistrad"}`))
+		case "/sets/noetag":
+			w.Write([]byte(`{"object":"set","code":"noetag"}`))
+		case "/cards/collection":
+			w.Header().Set("ETag", `"post"`)
+			w.Write([]byte(`{"object":"list","data":[]}`))
+		}
+	})
+	client := newTestClient(t, server)
+	client.cacheDir = t.TempDir()
+
+	cacheFiles := func() int {
+		t.Helper()
+		entries, err := os.ReadDir(client.cacheDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(entries)
+	}
+
+	for i := range 2 {
+		var set Set
+		if err := client.makeRequest("/sets/isd", &set); err != nil {
+			t.Fatal(err)
+		}
+		// the second answer is a bodiless 304, so the name must come from the cache
+		if set.Name != "Innistrad" {
+			t.Errorf("request %d decoded %+v, want Innistrad", i+1, set)
+		}
+	}
+	if cacheFiles() != 1 {
+		t.Fatalf("cache holds %d entries after the ETag response, want 1", cacheFiles())
+	}
+
+	for range 2 {
+		var set Set
+		if err := client.makeRequest("/sets/noetag", &set); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := client.getCollection(context.Background(), []CardIdentifier{{Name: "Opt"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cacheFiles() != 1 {
+		t.Errorf("cache holds %d entries, want only the ETag GET cached", cacheFiles())
+	}
+
+	want := []string{
+		`GET /sets/isd `,
+		`GET /sets/isd "v1"`,
+		`GET /sets/noetag `,
+		`POST /cards/collection `,
+		`GET /sets/noetag `,
+		`POST /cards/collection `,
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("requests %q, want %q", requests, want)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Errorf("request %d = %q, want %q", i+1, requests[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,597 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// releaseDateLayout is the YYYY-MM-DD format Scryfall uses for released_at
+const releaseDateLayout = "2006-01-02"
+
+// PowerValue returns this card's power as an integer.
+// ok is false when the card has no power or it is not a plain number ("*", "1+*", "X", "∞", "½", ...)
+func (c *Card) PowerValue() (int, bool) {
+	return parseStat(c.Power)
+}
+
+// ToughnessValue returns this card's toughness as an integer, see PowerValue
+func (c *Card) ToughnessValue() (int, bool) {
+	return parseStat(c.Toughness)
+}
+
+// LoyaltyValue returns this card's starting loyalty as an integer, see PowerValue
+func (c *Card) LoyaltyValue() (int, bool) {
+	return parseStat(c.Loyalty)
+}
+
+// DefenseValue returns this card's defense as an integer, see PowerValue
+func (c *Card) DefenseValue() (int, bool) {
+	return parseStat(c.Defense)
+}
+
+// IsVariablePower reports whether this card's power depends on the game state, like "*" or "1+*"
+func (c *Card) IsVariablePower() bool {
+	return isVariableStat(c.Power)
+}
+
+// IsVariableToughness reports whether this card's toughness depends on the game state, like "*" or "1+*"
+func (c *Card) IsVariableToughness() bool {
+	return isVariableStat(c.Toughness)
+}
+
+// PowerValue returns this face's power as an integer, see Card.PowerValue
+func (f *CardFace) PowerValue() (int, bool) {
+	return parseStat(f.Power)
+}
+
+// ToughnessValue returns this face's toughness as an integer, see Card.PowerValue
+func (f *CardFace) ToughnessValue() (int, bool) {
+	return parseStat(f.Toughness)
+}
+
+// LoyaltyValue returns this face's starting loyalty as an integer, see Card.PowerValue
+func (f *CardFace) LoyaltyValue() (int, bool) {
+	return parseStat(f.Loyalty)
+}
+
+// DefenseValue returns this face's defense as an integer, see Card.PowerValue
+func (f *CardFace) DefenseValue() (int, bool) {
+	return parseStat(f.Defense)
+}
+
+// parseStat converts a power/toughness/loyalty/defense string into an int.
+// Signed values like "-1" and "+2" (Vanguard) parse; anything else is reported as not ok.
+func parseStat(stat *string) (int, bool) {
+	if stat == nil {
+		return 0, false
+	}
+	value, err := strconv.Atoi(strings.TrimSpace(*stat))
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+// isVariableStat reports whether stat contains a variable component such as "*", "X" or "?"
+func isVariableStat(stat *string) bool {
+	if stat == nil {
+		return false
+	}
+	return strings.ContainsAny(*stat, "*Xx?")
+}
+
+// ReleaseDate parses ReleasedAt, the date this card was first released
+func (c *Card) ReleaseDate() (time.Time, error) {
+	if c.ReleasedAt == "" {
+		return time.Time{}, fmt.Errorf("card %s has no release date", c.Name)
+	}
+	return time.Parse(releaseDateLayout, c.ReleasedAt)
+}
+
+// WasPreviewed reports whether Scryfall credits a preview source for this printing
+func (c *Card) WasPreviewed() bool {
+	return c.Preview != nil && (c.Preview.Source != nil || c.Preview.PreviewedAt != nil)
+}
+
+// PreviewDate parses Preview.PreviewedAt, returning false when the printing has no
+// (or an unparseable) preview date
+func (c *Card) PreviewDate() (time.Time, bool) {
+	if c.Preview == nil || c.Preview.PreviewedAt == nil || *c.Preview.PreviewedAt == "" {
+		return time.Time{}, false
+	}
+	previewed, err := time.Parse(releaseDateLayout, *c.Preview.PreviewedAt)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return previewed, true
+}
+
+// IsDoubleFaced reports whether cards with this layout have a distinct back face
+func (l Layout) IsDoubleFaced() bool {
+	switch l {
+	case LayoutTransform, LayoutModalDFC, LayoutBattle, LayoutDoubleFacedToken, LayoutArtSeries, LayoutReversibleCard:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsSplit reports whether cards with this layout have two halves on the same face
+func (l Layout) IsSplit() bool {
+	return l == LayoutSplit
+}
+
+// LayoutKind returns Layout as a typed Layout
+func (c *Card) LayoutKind() Layout {
+	return Layout(c.Layout)
+}
+
+// LayoutKind returns this face's layout, only set for the faces of reversible cards
+func (f *CardFace) LayoutKind() Layout {
+	if f.Layout == nil {
+		return ""
+	}
+	return Layout(*f.Layout)
+}
+
+// IsDoubleFaced reports whether this card has a distinct back face (transform, modal DFC, ...)
+func (c *Card) IsDoubleFaced() bool {
+	return c.LayoutKind().IsDoubleFaced()
+}
+
+// IsSplit reports whether this is a split card like Fire // Ice
+func (c *Card) IsSplit() bool {
+	return c.LayoutKind().IsSplit()
+}
+
+// DisplayName returns the name to show for this printing. With preferPrinted,
+// non-English printings use their localized PrintedName; multi-face cards join
+// their faces' printed names as "A // B". Otherwise it's the English Name.
+func (c *Card) DisplayName(preferPrinted bool) string {
+	if preferPrinted {
+		if c.PrintedName != nil && *c.PrintedName != "" {
+			return *c.PrintedName
+		}
+		if printed, ok := joinFaceNames(c.CardFaces, true); ok {
+			return printed
+		}
+	}
+	if c.Name != "" {
+		return c.Name
+	}
+	name, _ := joinFaceNames(c.CardFaces, false)
+	return name
+}
+
+// joinFaceNames joins face names as "A // B". With printed it uses each face's
+// PrintedName and is only ok when every face has one.
+func joinFaceNames(faces []CardFace, printed bool) (string, bool) {
+	if len(faces) == 0 {
+		return "", false
+	}
+	names := make([]string, len(faces))
+	for i, face := range faces {
+		if !printed {
+			names[i] = face.Name
+			continue
+		}
+		if face.PrintedName == nil || *face.PrintedName == "" {
+			return "", false
+		}
+		names[i] = *face.PrintedName
+	}
+	return strings.Join(names, " // "), true
+}
+
+// HasFinish reports whether this printing is available in finish
+func (c *Card) HasFinish(finish Finish) bool {
+	for _, f := range c.Finishes {
+		if Finish(f) == finish {
+			return true
+		}
+	}
+	return false
+}
+
+// HasFoil reports whether this printing comes in foil
+func (c *Card) HasFoil() bool {
+	return c.HasFinish(FinishFoil)
+}
+
+// HasNonfoil reports whether this printing comes in nonfoil
+func (c *Card) HasNonfoil() bool {
+	return c.HasFinish(FinishNonfoil)
+}
+
+// HasEtched reports whether this printing comes in etched foil
+func (c *Card) HasEtched() bool {
+	return c.HasFinish(FinishEtched)
+}
+
+// AvailableFinishes returns Finishes as typed Finish values
+func (c *Card) AvailableFinishes() []Finish {
+	finishes := make([]Finish, len(c.Finishes))
+	for i, f := range c.Finishes {
+		finishes[i] = Finish(f)
+	}
+	return finishes
+}
+
+// HasGame reports whether this printing is available in game
+func (c *Card) HasGame(game Game) bool {
+	for _, g := range c.Games {
+		if Game(g) == game {
+			return true
+		}
+	}
+	return false
+}
+
+// AvailableGames returns Games as typed Game values
+func (c *Card) AvailableGames() []Game {
+	games := make([]Game, len(c.Games))
+	for i, g := range c.Games {
+		games[i] = Game(g)
+	}
+	return games
+}
+
+// IsOnArena reports whether this printing is available on MTG Arena
+func (c *Card) IsOnArena() bool {
+	return c.HasGame(GameArena)
+}
+
+// IsOnMTGO reports whether this printing is available on Magic: The Gathering Online
+func (c *Card) IsOnMTGO() bool {
+	return c.HasGame(GameMTGO)
+}
+
+// IsPaper reports whether this printing exists in paper
+func (c *Card) IsPaper() bool {
+	return c.HasGame(GamePaper)
+}
+
+// HasContentWarning reports whether Scryfall suggests not using this card's
+// imagery or text downstream, such as for cards with racist content
+func (c *Card) HasContentWarning() bool {
+	return c.ContentWarning != nil && *c.ContentWarning
+}
+
+// PopularityRank returns the card's EDHREC rank, lower being more popular, and
+// whether it has one
+func (c *Card) PopularityRank() (int, bool) {
+	if c.EDHRecRank == nil {
+		return 0, false
+	}
+	return *c.EDHRecRank, true
+}
+
+// PennyDreadfulRank returns the card's Penny Dreadful rank and whether it has one
+func (c *Card) PennyDreadfulRank() (int, bool) {
+	if c.PennyRank == nil {
+		return 0, false
+	}
+	return *c.PennyRank, true
+}
+
+// ComponentKind returns Component as a typed Component, normalized to Scryfall's
+// lowercase snake_case spelling so "meld-result" and "Meld_Result" match ComponentMeldResult
+func (r *RelatedCard) ComponentKind() Component {
+	return Component(strings.ReplaceAll(strings.ToLower(r.Component), "-", "_"))
+}
+
+// HasPromoType reports whether promoType (e.g. "boosterfun", "godzillaseries")
+// is one of this printing's PromoTypes, compared case-insensitively
+func (c *Card) HasPromoType(promoType string) bool {
+	for _, p := range c.PromoTypes {
+		if strings.EqualFold(p, promoType) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Card) hasFrameEffect(effect string) bool {
+	for _, e := range c.FrameEffects {
+		if e == effect {
+			return true
+		}
+	}
+	return false
+}
+
+// IsShowcase reports whether this printing uses a showcase frame
+func (c *Card) IsShowcase() bool {
+	return c.hasFrameEffect("showcase") || c.HasPromoType("showcase")
+}
+
+// IsBorderless reports whether this printing has no border
+func (c *Card) IsBorderless() bool {
+	return c.BorderColor == "borderless" || c.HasPromoType("borderless")
+}
+
+// IsExtendedArt reports whether this printing has extended art
+func (c *Card) IsExtendedArt() bool {
+	return c.hasFrameEffect("extendedart") || c.HasPromoType("extendedart")
+}
+
+// Value orders rarities the way Scryfall's r>= search does:
+// common < uncommon < rare < special < mythic < bonus. Unknown rarities are 0.
+func (r Rarity) Value() int {
+	switch Rarity(strings.ToLower(string(r))) {
+	case Common:
+		return 1
+	case Uncommon:
+		return 2
+	case Rare:
+		return 3
+	case Special:
+		return 4
+	case Mythic:
+		return 5
+	case Bonus:
+		return 6
+	default:
+		return 0
+	}
+}
+
+// Abbrev returns the one-letter rarity code used in set lists (C, U, R, S, M, B)
+func (r Rarity) Abbrev() string {
+	if r.Value() == 0 {
+		return "?"
+	}
+	return strings.ToUpper(string(r)[:1])
+}
+
+// AtLeast reports whether r is at or above other
+func (r Rarity) AtLeast(other Rarity) bool {
+	return r.Value() >= other.Value()
+}
+
+// ansiReset ends an ANSI color started by RarityColor
+const ansiReset = "\033[0m"
+
+// RarityColor returns the ANSI escape that colors terminal text in the rarity's
+// set-symbol color, or "" for unknown rarities
+func RarityColor(rarity string) string {
+	switch Rarity(strings.ToLower(rarity)) {
+	case Common:
+		return "\033[37m" // white
+	case Uncommon:
+		return "\033[36m" // silver
+	case Rare:
+		return "\033[33m" // gold
+	case Mythic:
+		return "\033[38;5;208m" // orange
+	case Special, Bonus:
+		return "\033[35m" // purple
+	default:
+		return ""
+	}
+}
+
+// RarityHexColor returns the rarity's set-symbol color as a hex string like "#A58E4A",
+// for HTML and other non-terminal output, or "" for unknown rarities
+func RarityHexColor(rarity string) string {
+	switch Rarity(strings.ToLower(rarity)) {
+	case Common:
+		return "#1A1718"
+	case Uncommon:
+		return "#707883"
+	case Rare:
+		return "#A58E4A"
+	case Mythic:
+		return "#BF4427"
+	case Special, Bonus:
+		return "#652978"
+	default:
+		return ""
+	}
+}
+
+// FormatRarity returns the rarity's abbreviation, wrapped in its ANSI color when
+// colorize is set. Leave colorize off when output may be piped or written to a file.
+func FormatRarity(rarity string, colorize bool) string {
+	abbrev := Rarity(strings.ToLower(rarity)).Abbrev()
+	color := RarityColor(rarity)
+	if !colorize || color == "" {
+		return abbrev
+	}
+	return color + abbrev + ansiReset
+}
+
+// RarityKind returns Rarity as a typed Rarity
+func (c *Card) RarityKind() Rarity {
+	return Rarity(c.Rarity)
+}
+
+// ImageStatusKind returns ImageStatus as a typed ImageStatus
+func (c *Card) ImageStatusKind() ImageStatus {
+	return ImageStatus(c.ImageStatus)
+}
+
+// HasUsableImage reports whether the card's image is a real scan, low resolution or
+// high, rather than missing or a placeholder
+func (c *Card) HasUsableImage() bool {
+	status := c.ImageStatusKind()
+	return status == ImageStatusLowRes || status == ImageStatusHighResScan
+}
+
+// EffectiveCMC returns CMC, or the front face's mana value for reversible_card
+// layouts, which only carry cmc on their faces
+func (c *Card) EffectiveCMC() float64 {
+	if c.LayoutKind() == LayoutReversibleCard && len(c.CardFaces) > 0 && c.CardFaces[0].CMC != nil {
+		return *c.CardFaces[0].CMC
+	}
+	return c.CMC
+}
+
+// EffectiveColors returns Colors, or the union of every face's colors in WUBRG order
+// when Colors is missing. Scryfall omits top-level colors for cards whose faces have
+// their own: transform, modal_dfc, battle, double_faced_token and reversible_card.
+func (c *Card) EffectiveColors() []string {
+	if c.Colors != nil || len(c.CardFaces) == 0 {
+		return c.Colors
+	}
+
+	present := make(map[string]bool)
+	for _, face := range c.CardFaces {
+		for _, color := range face.Colors {
+			present[color] = true
+		}
+	}
+	colors := []string{}
+	for _, color := range []string{"W", "U", "B", "R", "G"} {
+		if present[color] {
+			colors = append(colors, color)
+		}
+	}
+	return colors
+}
+
+// EffectiveTypeLine returns TypeLine, or the faces' type lines joined as "A // B"
+// when it is empty, as it is for reversible_card layouts
+func (c *Card) EffectiveTypeLine() string {
+	if c.TypeLine != "" || len(c.CardFaces) == 0 {
+		return c.TypeLine
+	}
+
+	typeLines := make([]string, 0, len(c.CardFaces))
+	for _, face := range c.CardFaces {
+		if face.TypeLine != nil {
+			typeLines = append(typeLines, *face.TypeLine)
+		}
+	}
+	return strings.Join(typeLines, " // ")
+}
+
+// supertypeWords are the type line words that come before a card's types. Token isn't
+// a supertype in the rules, but it's grouped here so it doesn't read as a card type.
+var supertypeWords = map[string]bool{
+	"Basic": true, "Elite": true, "Host": true, "Legendary": true,
+	"Ongoing": true, "Snow": true, "World": true, "Token": true,
+}
+
+// multiWordSubtypes are subtypes that contain a space and must not be split
+var multiWordSubtypes = []string{"Time Lord", "Bolas's Meditation Realm"}
+
+// Supertypes returns the card's supertypes (Legendary, Basic, Snow, ...),
+// from every face of a multi-faced card without repeats
+func (c *Card) Supertypes() []string {
+	supertypes, _, _ := parseTypeLine(c.EffectiveTypeLine())
+	return supertypes
+}
+
+// Types returns the card's types (Creature, Land, Instant, ...), see Supertypes
+func (c *Card) Types() []string {
+	_, types, _ := parseTypeLine(c.EffectiveTypeLine())
+	return types
+}
+
+// Subtypes returns the card's subtypes, the words after the em dash (Elf, Warrior,
+// Equipment, Forest, ...), see Supertypes
+func (c *Card) Subtypes() []string {
+	_, _, subtypes := parseTypeLine(c.EffectiveTypeLine())
+	return subtypes
+}
+
+// IsCreature reports whether any face of the card is a creature
+func (c *Card) IsCreature() bool {
+	return containsString(c.Types(), "Creature")
+}
+
+// IsLand reports whether any face of the card is a land
+func (c *Card) IsLand() bool {
+	return containsString(c.Types(), "Land")
+}
+
+// IsLegendary reports whether any face of the card is legendary
+func (c *Card) IsLegendary() bool {
+	return containsString(c.Supertypes(), "Legendary")
+}
+
+// parseTypeLine splits a type line such as "Legendary Creature — Elf Warrior" into its
+// supertypes, types and subtypes. Each face of a "A // B" type line is parsed in turn
+// and words already seen on an earlier face are not repeated.
+func parseTypeLine(typeLine string) (supertypes, types, subtypes []string) {
+	for _, face := range strings.Split(typeLine, " // ") {
+		left, right, _ := strings.Cut(face, "—")
+		for _, word := range strings.Fields(left) {
+			if supertypeWords[word] {
+				supertypes = appendUnique(supertypes, word)
+			} else {
+				types = appendUnique(types, word)
+			}
+		}
+		for _, word := range splitSubtypes(right) {
+			subtypes = appendUnique(subtypes, word)
+		}
+	}
+	return supertypes, types, subtypes
+}
+
+// splitSubtypes splits the subtype half of a type line on spaces, keeping
+// multiWordSubtypes together
+func splitSubtypes(subtypeLine string) []string {
+	var subtypes []string
+	subtypeLine = strings.TrimSpace(subtypeLine)
+	for subtypeLine != "" {
+		word, rest, _ := strings.Cut(subtypeLine, " ")
+		for _, multi := range multiWordSubtypes {
+			if strings.HasPrefix(subtypeLine, multi) && (len(subtypeLine) == len(multi) || subtypeLine[len(multi)] == ' ') {
+				word, rest = multi, subtypeLine[len(multi):]
+				break
+			}
+		}
+		subtypes = append(subtypes, word)
+		subtypeLine = strings.TrimSpace(rest)
+	}
+	return subtypes
+}
+
+func appendUnique(values []string, value string) []string {
+	if containsString(values, value) {
+		return values
+	}
+	return append(values, value)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ManaValue returns the card's mana value (converted mana cost), keeping any
+// fractional part: Un-set cards like Little Girl have a mana value of 0.5
+func (c *Card) ManaValue() float64 {
+	return c.EffectiveCMC()
+}
+
+// IsIntegralCMC reports whether the mana value is a whole number, which is
+// true of every card outside a handful of Un-set cards
+func (c *Card) IsIntegralCMC() bool {
+	value := c.ManaValue()
+	return value == math.Trunc(value)
+}
+
+// oracleID returns the card's oracle_id, falling back to its first face's for layouts
+// like reversible_card that only set it per face. ok is false when there is none.
+func (c *Card) oracleID() (string, bool) {
+	if c.OracleID != nil && *c.OracleID != "" {
+		return *c.OracleID, true
+	}
+	for _, face := range c.CardFaces {
+		if face.OracleID != nil && *face.OracleID != "" {
+			return *face.OracleID, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// GetCatalog returns the values of the named Scryfall catalog, e.g. "creature-types" or "keyword-abilities"
+func (c *Client) GetCatalog(name string) ([]string, error) {
+	var catalog Catalog
+	if err := c.makeRequest("/catalog/"+url.PathEscape(name), &catalog); err != nil {
+		return nil, fmt.Errorf("error fetching catalog %s: %v", name, err)
+	}
+	return catalog.Data, nil
+}
+
+// CardNames returns every English card name on Scryfall
+func (c *Client) CardNames() ([]string, error) {
+	return c.GetCatalog("card-names")
+}
+
+// ArtistNames returns every artist name on Scryfall
+func (c *Client) ArtistNames() ([]string, error) {
+	return c.GetCatalog("artist-names")
+}
+
+// CreatureTypes returns every creature subtype, e.g. "Elf" or "Warrior"
+func (c *Client) CreatureTypes() ([]string, error) {
+	return c.GetCatalog("creature-types")
+}
+
+// LandTypes returns every land subtype, e.g. "Forest" or "Gate"
+func (c *Client) LandTypes() ([]string, error) {
+	return c.GetCatalog("land-types")
+}
+
+// KeywordAbilities returns every keyword ability, e.g. "Flying" or "Ward"
+func (c *Client) KeywordAbilities() ([]string, error) {
+	return c.GetCatalog("keyword-abilities")
+}
+
+// KeywordActions returns every keyword action, e.g. "Scry" or "Investigate"
+func (c *Client) KeywordActions() ([]string, error) {
+	return c.GetCatalog("keyword-actions")
+}
+
+// AbilityWords returns every ability word, e.g. "Landfall"
+func (c *Client) AbilityWords() ([]string, error) {
+	return c.GetCatalog("ability-words")
+}
+
+// Watermarks returns every watermark that appears on cards
+func (c *Client) Watermarks() ([]string, error) {
+	return c.GetCatalog("watermarks")
+}
+
+// Powers returns every value that appears in a card's power
+func (c *Client) Powers() ([]string, error) {
+	return c.GetCatalog("powers")
+}
+
+// Toughnesses returns every value that appears in a card's toughness
+func (c *Client) Toughnesses() ([]string, error) {
+	return c.GetCatalog("toughnesses")
+}
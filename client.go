@@ -1,104 +1,583 @@
 package main
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"database/sql"
-	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
+	"mime"
+	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/ninesl/scryfall-api/scryfall"
 	_ "modernc.org/sqlite"
 )
 
-//go:embed schema.sql
-var ddl string
-
 const (
 	APIBaseURL       = "https://api.scryfall.com"
 	DefaultUserAgent = "MTGScryfallClient/1.0"
 	DefaultAccept    = "application/json;q=0.9,*/*;q=0.8"
+
+	// Scryfall serves images, symbols and bulk files from CDNs separate from the API
+	DefaultImageBaseURL = "https://cards.scryfall.io"
+	DefaultBulkBaseURL  = "https://data.scryfall.io"
+
+	// Scryfall asks for 50-100ms between requests to api.scryfall.com
+	DefaultRequestDelay = 100 * time.Millisecond
+
+	// DefaultTimeout bounds each API request made by the default http.Client, and how
+	// long downloads wait for response headers
+	DefaultTimeout = 30 * time.Second
+
+	// DefaultMaxRetries is how many times a request that hit a transient network error is retried
+	DefaultMaxRetries = 3
+
+	// retryBaseDelay is the wait before the first retry, doubled for each one after
+	retryBaseDelay = 500 * time.Millisecond
 )
 
 var (
 	DefaultClientOptions = ClientOptions{
-		APIURL:    APIBaseURL,
-		UserAgent: DefaultUserAgent,
-		Accept:    DefaultAccept,
-		Client:    &http.Client{},
+		APIURL:       APIBaseURL,
+		ImageBaseURL: DefaultImageBaseURL,
+		BulkBaseURL:  DefaultBulkBaseURL,
+		UserAgent:    DefaultUserAgent,
+		Accept:       DefaultAccept,
+		Timeout:      DefaultTimeout,
+		MaxRetries:   DefaultMaxRetries,
 	}
 )
 
 type Client struct {
-	baseURL   string
-	userAgent string
-	accept    string
-	client    *http.Client
-	db        *sql.DB
+	baseURL      string
+	imageBaseURL string
+	bulkBaseURL  string
+	userAgent    string
+	accept       string
+	client       *http.Client
+	// downloads of images and bulk files, which can take much longer than an API call
+	downloadClient *http.Client
+	db             *sql.DB
+
+	// rate limiting between API requests
+	rateMu      sync.Mutex
+	lastRequest time.Time
+
+	onProgress func(done, total int, msg string)
+	progressMu sync.Mutex
+
+	cacheDir string
+
+	onRequest  func(method, url string)
+	onResponse func(url string, status int, dur time.Duration)
+
+	logger *slog.Logger
+
+	maxRetries int
+	maxPages   int
+
+	storeFields map[string]bool // nil stores every printings column
+
+	trustURIHost        bool
+	continueOnPageError bool
+	hideContentWarnings bool
+	storeRawJSON        bool
+
+	// PriceIn conversion rates, units per US dollar
+	ratesMu       sync.RWMutex
+	exchangeRates map[Currency]float64
+
+	// CurrentStandardSets result, kept for the client's lifetime
+	standardMu       sync.Mutex
+	standardSets     []Set
+	standardComputed bool // standardSets is nil both before and for an empty result
+
+	// CardSet and GetSets results by set code, kept for the client's lifetime
+	setCacheMu sync.Mutex
+	setCache   map[string]*Set
 }
 
 type ClientOptions struct {
-	APIURL    string       // default is "https://api.scryfall.com"
-	UserAgent string       // API docs recomend "{AppName}/1.0"
-	Accept    string       // "application/json;q=0.9,*/*;q=0.8". could be used to take csv? TODO:
-	Client    *http.Client // any http client can be used
+	APIURL    string        // default is "https://api.scryfall.com"
+	UserAgent string        // API docs recomend "{AppName}/1.0"
+	Accept    string        // "application/json;q=0.9,*/*;q=0.8". SearchCardsText/SearchCardsCSV cover other formats
+	Client    *http.Client  // any http client can be used, for API calls and downloads alike
+	Timeout   time.Duration // only applies when Client is nil, default is DefaultTimeout; downloads only time out waiting for headers
+
+	// ImageBaseURL and BulkBaseURL replace the scheme and host of card image and
+	// set/symbol SVG URLs, and of bulk data file URLs, before they are downloaded.
+	// Point them at a proxy or test server; empty uses Scryfall's CDNs.
+	ImageBaseURL string
+	BulkBaseURL  string
+
+	// OnProgress is called as crawls and batch operations make progress.
+	// Calls are serialized, so it doesn't need its own locking. nil is silent.
+	OnProgress func(done, total int, msg string)
+
+	// CacheDir enables an on-disk cache of GET responses. Cached responses are
+	// revalidated with If-None-Match and reused when Scryfall answers 304 Not Modified.
+	// Empty disables caching.
+	CacheDir string
+
+	// OnRequest and OnResponse are called around every API request, for metrics or logging.
+	// status is 0 when the request failed without a response. nil hooks are skipped.
+	OnRequest  func(method, url string)
+	OnResponse func(url string, status int, dur time.Duration)
+
+	// DisableDB skips opening scryfall.db, for clients that only use the API.
+	// Methods that read or write the local database then return ErrDatabaseDisabled.
+	DisableDB bool
+
+	// Logger receives warnings and errors the client recovers from, such as a card
+	// that fails to insert mid-crawl. nil discards them.
+	Logger *slog.Logger
+
+	// StoreFields limits which printings columns the crawl writes, by column name
+	// ("set", "collector_number", "prices", ...). id and oracle_id are always stored;
+	// other columns are left NULL or empty. Empty stores every column.
+	StoreFields []string
+
+	// TrustURIHost skips checking that list and next_page URIs returned by the API,
+	// such as a card's prints_search_uri, point at APIURL's host before they are followed.
+	// Set it when APIURL is a proxy or test server that relays Scryfall's own URIs.
+	TrustURIHost bool
+
+	// ContinueOnPageError skips a page of a multi-page list (search results, a set's
+	// cards) that fails to load instead of stopping there. The cards from every other
+	// page are returned along with an error listing the pages that were skipped.
+	ContinueOnPageError bool
+
+	// HideContentWarnings leaves cards flagged with content_warning out of ExportBulk.
+	// Use the NoContentWarning filter for other card lists.
+	HideContentWarnings bool
+
+	// StoreRawJSON saves each printing's card object exactly as Scryfall returned it in
+	// the raw_json column, so fields the typed columns don't cover aren't lost. Read it
+	// back with GetRawCardJSON. It roughly doubles the database's size.
+	StoreRawJSON bool
+
+	// MaxRetries is how many times a request is retried after a transient network error
+	// (a timeout, a reset or refused connection), waiting twice as long before each retry.
+	// Canceled contexts, malformed URLs and error responses from Scryfall are never
+	// retried. 0 disables retries; DefaultClientOptions uses DefaultMaxRetries.
+	MaxRetries int
+
+	// MaxPages caps how many pages of a multi-page list (search results, a set's cards,
+	// a card's printings) are fetched. When a list has more, the cards from the pages
+	// fetched are returned along with ErrMaxPagesReached. 0 means no limit.
+	MaxPages int
 }
 
 // Uses DefaultClientOptions
 func NewClient(appName string) (*Client, error) {
-	DefaultClientOptions.UserAgent = fmt.Sprintf("%s/1.0", strings.TrimSpace(appName))
-	return NewClientWithOptions(DefaultClientOptions)
+	return NewClientWithHTTPClient(appName, nil)
+}
+
+// NewClientWithHTTPClient is NewClient sending requests through httpClient, for a custom
+// transport such as a proxy or instrumentation. nil uses DefaultClientOptions.Client.
+func NewClientWithHTTPClient(appName string, httpClient *http.Client) (*Client, error) {
+	appName = strings.TrimSpace(appName)
+	if appName == "" {
+		return nil, fmt.Errorf("app name is required for the User-Agent header")
+	}
+	// copy the defaults rather than setting the User-Agent on the shared global
+	options := DefaultClientOptions
+	options.UserAgent = fmt.Sprintf("%s/1.0", appName)
+	if httpClient != nil {
+		options.Client = httpClient
+	}
+	return NewClientWithOptions(options)
 }
 
 func NewClientWithOptions(co ClientOptions) (*Client, error) {
-	// Initialize database
-	db, err := sql.Open("sqlite", "scryfall.db")
+	// Scryfall requires a meaningful User-Agent and may block requests without one
+	if userAgent := strings.TrimSpace(co.UserAgent); userAgent == "" || strings.HasPrefix(userAgent, "/") {
+		return nil, fmt.Errorf("invalid User-Agent %q, use something like \"AppName/1.0\"", co.UserAgent)
+	}
+	if strings.TrimSpace(co.Accept) == "" {
+		co.Accept = DefaultAccept
+	}
+	downloadClient := co.Client
+	if co.Client == nil {
+		timeout := co.Timeout
+		if timeout == 0 {
+			timeout = DefaultTimeout
+		}
+		// Timeout covers reading the whole body, which a bulk file can't do in 30s,
+		// so downloads only bound the wait for the response headers
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.ResponseHeaderTimeout = timeout
+		co.Client = &http.Client{Timeout: timeout, Transport: transport}
+		downloadClient = &http.Client{Transport: transport}
+	}
+	storeFields, err := storeFieldSet(co.StoreFields)
 	if err != nil {
 		return nil, err
 	}
+	if storeFields != nil && co.StoreRawJSON {
+		storeFields["raw_json"] = true
+	}
+	if co.Logger == nil {
+		co.Logger = slog.New(slog.DiscardHandler)
+	}
+	if co.ImageBaseURL == "" {
+		co.ImageBaseURL = DefaultImageBaseURL
+	}
+	if co.BulkBaseURL == "" {
+		co.BulkBaseURL = DefaultBulkBaseURL
+	}
 
-	// Create tables if they don't exist
-	if _, err := db.Exec(ddl); err != nil {
-		db.Close()
-		return nil, err
+	if co.CacheDir != "" {
+		if err := os.MkdirAll(co.CacheDir, 0o755); err != nil {
+			return nil, fmt.Errorf("error creating cache dir: %v", err)
+		}
+	}
+
+	// Initialize database
+	var db *sql.DB
+	if !co.DisableDB {
+		var err error
+		if db, err = sql.Open("sqlite", "scryfall.db"); err != nil {
+			return nil, err
+		}
+		if err := initSchema(db); err != nil {
+			db.Close()
+			return nil, err
+		}
 	}
 
 	return &Client{
-		baseURL:   co.APIURL,
-		userAgent: co.UserAgent,
-		accept:    co.Accept,
-		client:    co.Client,
-		db:        db,
+		baseURL:             co.APIURL,
+		imageBaseURL:        co.ImageBaseURL,
+		bulkBaseURL:         co.BulkBaseURL,
+		userAgent:           co.UserAgent,
+		accept:              co.Accept,
+		client:              co.Client,
+		downloadClient:      downloadClient,
+		db:                  db,
+		onProgress:          co.OnProgress,
+		cacheDir:            co.CacheDir,
+		onRequest:           co.OnRequest,
+		onResponse:          co.OnResponse,
+		logger:              co.Logger,
+		storeFields:         storeFields,
+		trustURIHost:        co.TrustURIHost,
+		continueOnPageError: co.ContinueOnPageError,
+		hideContentWarnings: co.HideContentWarnings,
+		storeRawJSON:        co.StoreRawJSON,
+		maxRetries:          max(co.MaxRetries, 0),
+		maxPages:            max(co.MaxPages, 0),
 	}, nil
 }
 
 func (c *Client) makeRequest(endpoint string, result interface{}) error {
+	return c.makeRequestContext(context.Background(), endpoint, result)
+}
+
+// makeRequestContext is makeRequest with a context that can cancel the request
+func (c *Client) makeRequestContext(ctx context.Context, endpoint string, result interface{}) error {
+	return c.doRequest(ctx, "GET", endpoint, nil, result)
+}
+
+// makePostRequest sends body encoded as JSON, as required by endpoints like /cards/collection
+func (c *Client) makePostRequest(ctx context.Context, endpoint string, body interface{}, result interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return c.doRequest(ctx, "POST", endpoint, bytes.NewReader(payload), result)
+}
+
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, body io.Reader, result interface{}) error {
+	return c.doRequestFunc(ctx, method, endpoint, body, func(r io.Reader) error {
+		return json.NewDecoder(r).Decode(result)
+	})
+}
+
+// doRequestFunc sends a request and hands the successful response body to decode,
+// so callers can stream large responses or read non-JSON ones
+func (c *Client) doRequestFunc(ctx context.Context, method, endpoint string, body io.Reader, decode func(io.Reader) error) error {
 	fullURL := c.baseURL + endpoint
 
-	req, err := http.NewRequest("GET", fullURL, nil)
+	// the body is read once so it can be resent if the request has to be retried
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return err
+		}
+	}
+	newRequest := func() (*http.Request, error) {
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(payload)
+		}
+		return http.NewRequestWithContext(ctx, method, fullURL, reqBody)
+	}
+
+	req, err := newRequest()
+	if err != nil {
+		return err
+	}
+
+	var cached *cacheEntry
+	if method == "GET" {
+		cached = c.loadCache(fullURL)
+	}
+
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			if req, err = newRequest(); err != nil {
+				return err
+			}
+		}
+		req.Header.Set("User-Agent", c.userAgent)
+		req.Header.Set("Accept", c.accept)
+		req.Header.Set("Accept-Encoding", "gzip")
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if cached != nil {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+
+		c.waitForRateLimit()
+		if c.onRequest != nil {
+			c.onRequest(method, fullURL)
+		}
+		start := time.Now()
+		resp, err = c.client.Do(req)
+		if c.onResponse != nil {
+			status := 0
+			if resp != nil {
+				status = resp.StatusCode
+			}
+			c.onResponse(fullURL, status, time.Since(start))
+		}
+		if err == nil {
+			break
+		}
+		if attempt >= c.maxRetries || !isRetriable(ctx, err) {
+			return err
+		}
+
+		delay := retryBaseDelay << attempt
+		c.logger.Warn("retrying request", "url", fullURL, "attempt", attempt+1, "delay", delay, "err", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+	defer resp.Body.Close()
+
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		return decode(bytes.NewReader(cached.Body))
+	}
+
+	respBody, err := decodedBody(resp)
+	if err != nil {
+		// a broken error body still leaves the status to report
+		if resp.StatusCode != http.StatusOK {
+			return newAPIError(resp.StatusCode, http.NoBody)
+		}
+		return err
+	}
+	defer respBody.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp.StatusCode, respBody)
+	}
+
+	if method != "GET" || c.cacheDir == "" || resp.Header.Get("ETag") == "" {
+		return decode(respBody)
+	}
+
+	data, err := io.ReadAll(respBody)
 	if err != nil {
 		return err
 	}
+	if err := decode(bytes.NewReader(data)); err != nil {
+		return err
+	}
+	if err := c.storeCache(fullURL, resp.Header.Get("ETag"), data); err != nil {
+		c.logger.Warn("error caching response", "url", fullURL, "err", err)
+	}
+	return nil
+}
+
+// isRetriable reports whether a failed request hit a transient network error, such as
+// a timeout or a reset connection, that is worth retrying. A canceled or expired ctx
+// never is.
+func isRetriable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// APIError is returned when Scryfall answers with a non-200 status.
+// Code, Type, Details and Warnings come from Scryfall's error object when the body has one.
+type APIError struct {
+	Status   int
+	Code     string // e.g. "not_found", "bad_request"
+	Type     string // a further classification, e.g. "ambiguous"; often empty
+	Details  string
+	Warnings []string // problems with the request, such as ignored search terms
+}
 
+func (e *APIError) Error() string {
+	if e.Details == "" {
+		return fmt.Sprintf("API request failed with status %d", e.Status)
+	}
+	return fmt.Sprintf("API request failed with status %d: %s", e.Status, e.Details)
+}
+
+// newAPIError builds an APIError from a failed response, decoding Scryfall's error object if present
+func newAPIError(status int, respBody io.Reader) *APIError {
+	apiErr := &APIError{Status: status}
+	var body struct {
+		Code     string   `json:"code"`
+		Type     string   `json:"type"`
+		Details  string   `json:"details"`
+		Warnings []string `json:"warnings"`
+	}
+	if err := json.NewDecoder(respBody).Decode(&body); err == nil {
+		apiErr.Code = body.Code
+		apiErr.Type = body.Type
+		apiErr.Details = body.Details
+		apiErr.Warnings = body.Warnings
+	}
+	return apiErr
+}
+
+// download streams the file at fileURL into w. It is meant for Scryfall's CDN
+// (svgs.scryfall.io, cards.scryfall.io), which isn't subject to the API rate limit.
+// accept is sent as the Accept header; unless it is "*/*" the response's Content-Type
+// must match it, so an HTML error page isn't saved in place of the file.
+func (c *Client) download(fileURL, accept string, w io.Writer) error {
+	fileURL = c.cdnURL(fileURL)
+	req, err := http.NewRequest("GET", fileURL, nil)
+	if err != nil {
+		return err
+	}
 	req.Header.Set("User-Agent", c.userAgent)
-	req.Header.Set("Accept", c.accept)
+	req.Header.Set("Accept", accept)
+	req.Header.Set("Accept-Encoding", "gzip")
 
-	resp, err := c.client.Do(req)
+	resp, err := c.downloadClient.Do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return fmt.Errorf("download of %s failed with status %d", fileURL, resp.StatusCode)
+	}
+	if accept != "*/*" {
+		contentType := resp.Header.Get("Content-Type")
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || mediaType != accept {
+			return fmt.Errorf("download of %s returned %q, expected %s", fileURL, contentType, accept)
+		}
+	}
+
+	respBody, err := decodedBody(resp)
+	if err != nil {
+		return err
+	}
+	defer respBody.Close()
+
+	_, err = io.Copy(w, respBody)
+	return err
+}
+
+// cdnURL points a Scryfall CDN URL at the configured image or bulk base URL.
+// Other URLs are returned unchanged.
+func (c *Client) cdnURL(fileURL string) string {
+	parsed, err := url.Parse(fileURL)
+	if err != nil {
+		return fileURL
+	}
+
+	var base string
+	switch parsed.Host {
+	case "cards.scryfall.io", "svgs.scryfall.io":
+		base = c.imageBaseURL
+	case "data.scryfall.io":
+		base = c.bulkBaseURL
+	default:
+		return fileURL
 	}
 
-	return json.NewDecoder(resp.Body).Decode(result)
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return fileURL
+	}
+	parsed.Scheme = baseURL.Scheme
+	parsed.Host = baseURL.Host
+	parsed.Path = strings.TrimSuffix(baseURL.Path, "/") + parsed.Path
+	return parsed.String()
+}
+
+// decodedBody returns the response body, decompressing it when the server sent it gzipped.
+// Setting Accept-Encoding ourselves turns off net/http's transparent decompression.
+func decodedBody(resp *http.Response) (io.ReadCloser, error) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return io.NopCloser(resp.Body), nil
+	}
+	reader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading gzip response: %v", err)
+	}
+	return reader, nil
+}
+
+// reportProgress forwards progress to the OnProgress callback, if one is set
+func (c *Client) reportProgress(done, total int, msg string) {
+	if c.onProgress == nil {
+		return
+	}
+	c.progressMu.Lock()
+	defer c.progressMu.Unlock()
+	c.onProgress(done, total, msg)
+}
+
+// waitForRateLimit blocks until DefaultRequestDelay has passed since the previous request
+func (c *Client) waitForRateLimit() {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+
+	if wait := DefaultRequestDelay - time.Since(c.lastRequest); wait > 0 {
+		time.Sleep(wait)
+	}
+	c.lastRequest = time.Now()
 }
 
 func (c *Client) getCard(id string) (*Card, error) {
@@ -107,16 +586,15 @@ func (c *Client) getCard(id string) (*Card, error) {
 	return &card, err
 }
 
-func (c *Client) getSet(code string) (*Set, error) {
+func (c *Client) getSet(ctx context.Context, code string) (*Set, error) {
 	var set Set
-	err := c.makeRequest("/sets/"+url.PathEscape(code), &set)
+	err := c.makeRequestContext(ctx, "/sets/"+url.PathEscape(code), &set)
 	return &set, err
 }
 
-func (c *Client) searchCards(query string) (*List, error) {
-	var list List
-	err := c.makeRequest("/cards/search?q="+url.QueryEscape(query), &list)
-	return &list, err
+// searchCards returns every card matching query, walking all pages of results
+func (c *Client) searchCards(ctx context.Context, query string) ([]Card, []string, error) {
+	return c.SearchCardsWithOptionsContext(ctx, query, SearchOptions{})
 }
 
 func (c *Client) searchCardsByName(name string) (*List, error) {
@@ -126,32 +604,181 @@ func (c *Client) searchCardsByName(name string) (*List, error) {
 	return &list, err
 }
 
-func (c *Client) getCardPrintings(printsSearchURI string) (*List, error) {
+// getCardPrintings returns every printing from a card's prints_search_uri
+func (c *Client) getCardPrintings(ctx context.Context, printsSearchURI string) ([]Card, error) {
+	printings, _, err := c.followList(ctx, printsSearchURI, c.maxPages)
+	return printings, err
+}
+
+// getList requests a single page of a List from a full API URI (prints_search_uri, search_uri, next_page, ...)
+func (c *Client) getList(ctx context.Context, listURI string) (*List, error) {
 	var list List
-	// Extract the path from the full URI
-	parsedURL, err := url.Parse(printsSearchURI)
+	endpoint, err := c.apiEndpoint(listURI)
 	if err != nil {
 		return nil, err
 	}
-	endpoint := parsedURL.Path + "?" + parsedURL.RawQuery
-	err = c.makeRequest(endpoint, &list)
+	if !c.storeRawJSON {
+		err = c.makeRequestContext(ctx, endpoint, &list)
+		return &list, err
+	}
+
+	// decode the page twice, once to keep each card's original JSON for the crawl to store
+	var raw struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	err = c.doRequestFunc(ctx, "GET", endpoint, nil, func(r io.Reader) error {
+		body, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(body, &list); err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &raw)
+	})
+	if err == nil && len(raw.Data) == len(list.Data) {
+		for i := range list.Data {
+			list.Data[i].raw = raw.Data[i]
+		}
+	}
 	return &list, err
 }
 
-// Helper functions
+// apiEndpoint returns the path and query of a URI from the API (a list page, set_uri, ...),
+// to be requested against c.baseURL. Unless trustURIHost is set, a URI on any other
+// host is an error.
+func (c *Client) apiEndpoint(uri string) (string, error) {
+	parsedURL, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if !c.trustURIHost && parsedURL.Host != "" {
+		base, err := url.Parse(c.baseURL)
+		if err != nil {
+			return "", err
+		}
+		if !strings.EqualFold(parsedURL.Host, base.Host) {
+			return "", fmt.Errorf("URI %q is not on the API host %q", uri, base.Host)
+		}
+	}
+	return parsedURL.Path + "?" + parsedURL.RawQuery, nil
+}
+
+// followList walks the List at startURI page by page and returns all of its cards
+// along with every warning Scryfall attached to any page. At most maxPages pages are
+// fetched (0 means no limit); when the list has more, ErrMaxPagesReached is returned
+// with the cards fetched so far. The crawl stops as soon as ctx is cancelled.
+//
+// When a page fails, the cards from earlier pages are returned along with the error.
+// With continueOnPageError set, a failed page after the first is logged and skipped
+// instead, and the errors of every skipped page are returned once the list is done.
+func (c *Client) followList(ctx context.Context, startURI string, maxPages int) ([]Card, []string, error) {
+	var cards []Card
+	var warnings []string
+	var pageErrs []error
+	lastPage := 0 // known once a page reports total_cards
+
+	listURI := startURI
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return cards, warnings, err
+		}
+
+		list, err := c.getList(ctx, listURI)
+		if err != nil {
+			if !c.continueOnPageError || page == 1 || ctx.Err() != nil {
+				return cards, warnings, err
+			}
+			c.logger.Warn("skipping failed page", "page", page, "url", listURI, "err", err)
+			pageErrs = append(pageErrs, fmt.Errorf("page %d: %v", page, err))
+
+			if page >= lastPage {
+				return cards, warnings, errors.Join(pageErrs...)
+			}
+			if maxPages > 0 && page >= maxPages {
+				return cards, warnings, errors.Join(append(pageErrs, ErrMaxPagesReached)...)
+			}
+			if listURI, err = nextPageURI(listURI); err != nil {
+				return cards, warnings, errors.Join(append(pageErrs, err)...)
+			}
+			continue
+		}
+		cards = append(cards, list.Data...)
+		warnings = append(warnings, list.Warnings...)
+		if page == 1 && len(list.Data) > 0 {
+			lastPage = (list.TotalCards + len(list.Data) - 1) / len(list.Data)
+		}
+		// single-page lists (most printings lookups) finish too fast to be worth reporting
+		if page > 1 || list.HasMore {
+			c.reportProgress(len(cards), list.TotalCards, fmt.Sprintf("Fetched %d of %d cards", len(cards), list.TotalCards))
+		}
 
-// Helper function to convert int slice to comma-separated string
-func intsToString(ints []int) string {
-	if len(ints) == 0 {
-		return ""
+		if !list.HasMore || list.NextPage == nil {
+			return cards, warnings, errors.Join(pageErrs...)
+		}
+		if maxPages > 0 && page >= maxPages {
+			return cards, warnings, errors.Join(append(pageErrs, ErrMaxPagesReached)...)
+		}
+		listURI = list.NextPage.String()
 	}
-	strs := make([]string, len(ints))
-	for i, v := range ints {
-		strs[i] = strconv.Itoa(v)
+}
+
+// nextPageURI returns listURI with its page parameter incremented, which is how
+// Scryfall numbers the pages of a list. A URI without one is page 1.
+func nextPageURI(listURI string) (string, error) {
+	parsed, err := url.Parse(listURI)
+	if err != nil {
+		return "", err
+	}
+	query := parsed.Query()
+	page := 1
+	if p := query.Get("page"); p != "" {
+		if page, err = strconv.Atoi(p); err != nil {
+			return "", fmt.Errorf("invalid page %q in %s", p, listURI)
+		}
 	}
-	return strings.Join(strs, ",")
+	query.Set("page", strconv.Itoa(page+1))
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), nil
 }
 
+// getCollection looks up cards through /cards/collection, maxCollectionSize identifiers
+// per request. The returned List holds every card found, in request order, and the
+// identifiers Scryfall couldn't match are returned alongside it. If a request fails,
+// the cards and not-found identifiers from earlier requests are returned with the error.
+func (c *Client) getCollection(ctx context.Context, identifiers []CardIdentifier) (*List, []CardIdentifier, error) {
+	found := List{Object: "list"}
+	var notFound []CardIdentifier
+
+	for start := 0; start < len(identifiers); start += maxCollectionSize {
+		end := start + maxCollectionSize
+		if end > len(identifiers) {
+			end = len(identifiers)
+		}
+
+		var list List
+		body := struct {
+			Identifiers []CardIdentifier `json:"identifiers"`
+		}{identifiers[start:end]}
+		if err := c.makePostRequest(ctx, "/cards/collection", body, &list); err != nil {
+			return &found, notFound, fmt.Errorf("collection request for identifiers %d-%d: %v", start+1, end, err)
+		}
+		found.Data = append(found.Data, list.Data...)
+		found.Warnings = append(found.Warnings, list.Warnings...)
+		notFound = append(notFound, list.NotFound...)
+		found.TotalCards = len(found.Data)
+		found.NotFound = notFound
+
+		if len(identifiers) > maxCollectionSize {
+			c.reportProgress(end, len(identifiers), fmt.Sprintf("Looked up %d of %d cards", end, len(identifiers)))
+		}
+	}
+
+	return &found, notFound, nil
+}
+
+// Helper functions
+
 // Helper function to convert pointer to sql.NullString
 func ptrToNullString(s *string) sql.NullString {
 	if s == nil {
@@ -176,6 +803,33 @@ func ptrToNullBool(b *bool) sql.NullBool {
 	return sql.NullBool{Bool: *b, Valid: true}
 }
 
+// Helper function to convert sql.NullString to pointer
+func nullStringToPtr(ns sql.NullString) *string {
+	if !ns.Valid {
+		return nil
+	}
+	s := ns.String
+	return &s
+}
+
+// Helper function to convert sql.NullInt64 to pointer
+func nullInt64ToPtr(ni sql.NullInt64) *int {
+	if !ni.Valid {
+		return nil
+	}
+	i := int(ni.Int64)
+	return &i
+}
+
+// Helper function to convert sql.NullBool to pointer
+func nullBoolToPtr(nb sql.NullBool) *bool {
+	if !nb.Valid {
+		return nil
+	}
+	b := nb.Bool
+	return &b
+}
+
 // Helper function to convert string to sql.NullString
 func stringToNullString(s string) sql.NullString {
 	if s == "" {
@@ -217,6 +871,14 @@ func toJSONString(v interface{}) sql.NullString {
 	return sql.NullString{String: string(jsonBytes), Valid: true}
 }
 
+// rawJSONColumn stores a card's original JSON as is, or NULL when none was kept
+func rawJSONColumn(raw json.RawMessage) sql.NullString {
+	if len(raw) == 0 {
+		return sql.NullString{Valid: false}
+	}
+	return sql.NullString{String: string(raw), Valid: true}
+}
+
 // toJSONStringDirect converts interface{} to JSON string directly (not sql.NullString)
 func toJSONStringDirect(v interface{}) string {
 	if v == nil {
@@ -250,69 +912,71 @@ func toJSONStringDirect(v interface{}) string {
 	return string(jsonBytes)
 }
 
-// containsFinish checks if a finish type exists in the finishes array
-func containsFinish(finishes []string, finish string) bool {
-	for _, f := range finishes {
-		if f == finish {
-			return true
-		}
-	}
-	return false
-}
-
-func isArenaSet(games []string) bool {
-	for _, game := range games {
-		if game == "arena" {
-			return true
-		}
-	}
-	return false
-}
-
-func shouldIncludeCard(printings []Card) bool {
-	// Check if any printing is common/uncommon on Arena
+// shouldIncludeCard reports whether every printing of a card passes every filter
+func shouldIncludeCard(printings []Card, filters []CardFilter) bool {
 	for _, printing := range printings {
-		if isArenaSet(printing.Games) && (printing.Rarity == "common" || printing.Rarity == "uncommon") {
+		if !matchesAll(printing, filters) {
 			return false
 		}
 	}
 	return true
 }
 
-// queryAndInsertCards fetches cards from Scryfall API and inserts them into database
-func (c *Client) queryAndInsertCards(db *sql.DB) error {
-	ctx := context.Background()
+// queryAndInsertCards searches Scryfall for searchQuery and inserts the cards it finds
+// into database. A card and its printings are only stored if every printing passes
+// every filter.
+func (c *Client) queryAndInsertCards(ctx context.Context, db *sql.DB, searchQuery string, filters ...CardFilter) error {
 	queries := scryfall.New(db)
 
-	searchQuery := "(game:paper game:mtgo -game:arena in:common or in:uncommon) game:arena r>=rare"
-	fmt.Printf("Searching for query: %s\n", searchQuery)
+	c.reportProgress(0, 0, fmt.Sprintf("Searching for query: %s", searchQuery))
 
-	results, err := c.searchCards(searchQuery)
-	if err != nil {
-		return fmt.Errorf("search error: %v", err)
+	results, warnings, searchErr := c.searchCards(ctx, searchQuery)
+	if searchErr != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if len(results) == 0 {
+			return fmt.Errorf("search error: %v", searchErr)
+		}
+		// keep what the search got before failing, then report the error once it's stored
+		c.logger.Error("search failed part way, storing partial results", "cards", len(results), "err", searchErr)
 	}
 
-	fmt.Printf("Found %d cards\n", results.TotalCards)
+	for _, warning := range warnings {
+		c.logger.Warn("Scryfall warning", "query", searchQuery, "warning", warning)
+	}
+
+	total := len(results)
+	c.reportProgress(0, total, fmt.Sprintf("Found %d cards", total))
 
 	insertedCount := 0
-	for _, card := range results.Data {
-		fmt.Printf("Fetching printings for %s...\n", card.Name)
+	for i, card := range results {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// Reversible cards keep oracle_id on their faces, and a few extras have none at all
+		oracleID, ok := card.oracleID()
+		if !ok {
+			c.logger.Warn("skipping card without oracle_id", "card", card.Name, "id", card.ID)
+			continue
+		}
 
-		printings, err := c.getCardPrintings(card.PrintsSearchURI.String())
+		c.reportProgress(i, total, fmt.Sprintf("Fetching printings for %s...", card.Name))
+
+		printings, err := c.getCardPrintings(ctx, card.PrintsSearchURI.String())
 		if err != nil {
-			log.Printf("Error fetching printings for %s: %v", card.Name, err)
+			c.logger.Error("error fetching printings", "card", card.Name, "err", err)
 			continue
 		}
 
-		// Filter out cards that have common/uncommon Arena printings
-		if !shouldIncludeCard(printings.Data) {
-			fmt.Printf("Skipping %s - has common/uncommon Arena printing\n", card.Name)
+		if !shouldIncludeCard(printings, filters) {
+			c.reportProgress(i+1, total, fmt.Sprintf("Skipping %s - a printing was rejected by the filters", card.Name))
 			continue
 		}
 
 		// First, insert the card (oracle-level data) - this will be upserted if it already exists
 		err = queries.UpsertCard(ctx, scryfall.UpsertCardParams{
-			OracleID:        *card.OracleID,
+			OracleID:        oracleID,
 			Name:            card.Name,
 			Layout:          card.Layout,
 			PrintsSearchUri: card.PrintsSearchURI.String(),
@@ -342,90 +1006,102 @@ func (c *Client) queryAndInsertCards(db *sql.DB) error {
 		})
 
 		if err != nil {
-			log.Printf("Error inserting card %s: %v", card.Name, err)
+			c.logger.Error("error inserting card", "card", card.Name, "err", err)
 			continue
 		}
 
-		// Then insert ALL printings of this card
-		for _, printing := range printings.Data {
-			err = queries.UpsertPrinting(ctx, scryfall.UpsertPrintingParams{
-				ID:                printing.ID,
-				OracleID:          *printing.OracleID,
-				ArenaID:           ptrToNullInt64(printing.ArenaID),
-				Lang:              printing.Lang,
-				MtgoID:            ptrToNullInt64(printing.MTGOID),
-				MtgoFoilID:        ptrToNullInt64(printing.MTGOFoilID),
-				MultiverseIds:     toJSONString(printing.MultiverseIDs),
-				TcgplayerID:       ptrToNullInt64(printing.TCGPlayerID),
-				TcgplayerEtchedID: ptrToNullInt64(printing.TCGPlayerEtchedID),
-				CardmarketID:      ptrToNullInt64(printing.CardmarketID),
-				Object:            printing.Object,
-				ScryfallUri:       printing.ScryfallURI.String(),
-				Uri:               printing.URI.String(),
-				Artist:            ptrToNullString(printing.Artist),
-				ArtistIds:         toJSONString(printing.ArtistIDs),
-				AttractionLights:  toJSONString(printing.AttractionLights),
-				Booster:           printing.Booster,
-				BorderColor:       printing.BorderColor,
-				CardBackID:        printing.CardBackID,
-				CollectorNumber:   printing.CollectorNumber,
-				ContentWarning:    ptrToNullBool(printing.ContentWarning),
-				Digital:           printing.Digital,
-				Finishes:          toJSONStringDirect(printing.Finishes),
-				FlavorName:        ptrToNullString(printing.FlavorName),
-				FlavorText:        ptrToNullString(printing.FlavorText),
-				Foil:              containsFinish(printing.Finishes, "foil"),
-				Nonfoil:           containsFinish(printing.Finishes, "nonfoil"),
-				FrameEffects:      toJSONString(printing.FrameEffects),
-				Frame:             printing.Frame,
-				FullArt:           printing.FullArt,
-				Games:             toJSONStringDirect(printing.Games),
-				HighresImage:      printing.HighresImage,
-				IllustrationID:    ptrToNullString(printing.IllustrationID),
-				ImageStatus:       printing.ImageStatus,
-				ImageUris:         toJSONString(printing.ImageURIs),
-				Oversized:         printing.Oversized,
-				Prices:            toJSONStringDirect(printing.Prices),
-				PrintedName:       ptrToNullString(printing.PrintedName),
-				PrintedText:       ptrToNullString(printing.PrintedText),
-				PrintedTypeLine:   ptrToNullString(printing.PrintedTypeLine),
-				Promo:             printing.Promo,
-				PromoTypes:        toJSONString(printing.PromoTypes),
-				PurchaseUris:      toJSONString(printing.PurchaseURIs),
-				Rarity:            printing.Rarity,
-				RelatedUris:       toJSONStringDirect(printing.RelatedURIs),
-				ReleasedAt:        printing.ReleasedAt,
-				Reprint:           printing.Reprint,
-				ScryfallSetUri:    printing.ScryfallSetURI.String(),
-				SetName:           printing.SetName,
-				SetSearchUri:      printing.SetSearchURI.String(),
-				SetType:           printing.SetType,
-				SetUri:            printing.SetURI.String(),
-				Set:               printing.Set,
-				SetID:             printing.SetID,
-				StorySpotlight:    printing.StorySpotlight,
-				Textless:          printing.Textless,
-				Variation:         printing.Variation,
-				VariationOf:       ptrToNullString(printing.VariationOf),
-				SecurityStamp:     ptrToNullString(printing.SecurityStamp),
-				Watermark:         ptrToNullString(printing.Watermark),
-				Preview:           toJSONString(printing.Preview),
-			})
-
-			if err != nil {
-				log.Printf("Error inserting printing %s (%s): %v", printing.Name, printing.Set, err)
-				continue
+		// Then insert ALL printings of this card in one batch
+		params := make([]scryfall.UpsertPrintingParams, len(printings))
+		for j, printing := range printings {
+			if printing.OracleID == nil {
+				printing.OracleID = &oracleID
 			}
-
-			insertedCount++
-			fmt.Printf("Inserted %s (%s - %s)\n", printing.Name, printing.Set, printing.Rarity)
+			params[j] = c.trimPrintingParams(printingParams(printing))
+		}
+		if err := queries.UpsertPrintings(ctx, params); err != nil {
+			c.logger.Error("error inserting printings", "card", card.Name, "err", err)
+			continue
 		}
+
+		insertedCount += len(params)
+		c.reportProgress(i+1, total, fmt.Sprintf("Inserted %d printings of %s", len(params), card.Name))
 	}
 
-	fmt.Printf("\nInserted %d filtered cards into database\n", insertedCount)
+	c.reportProgress(total, total, fmt.Sprintf("Inserted %d filtered cards into database", insertedCount))
+	if searchErr != nil {
+		return fmt.Errorf("search error after %d cards: %v", total, searchErr)
+	}
 	return nil
 }
 
+// printingParams converts a printing into the row stored in the printings table
+func printingParams(printing Card) scryfall.UpsertPrintingParams {
+	return scryfall.UpsertPrintingParams{
+		ID:                printing.ID,
+		OracleID:          *printing.OracleID,
+		ArenaID:           ptrToNullInt64(printing.ArenaID),
+		Lang:              printing.Lang,
+		MtgoID:            ptrToNullInt64(printing.MTGOID),
+		MtgoFoilID:        ptrToNullInt64(printing.MTGOFoilID),
+		MultiverseIds:     toJSONString(printing.MultiverseIDs),
+		TcgplayerID:       ptrToNullInt64(printing.TCGPlayerID),
+		TcgplayerEtchedID: ptrToNullInt64(printing.TCGPlayerEtchedID),
+		CardmarketID:      ptrToNullInt64(printing.CardmarketID),
+		Object:            printing.Object,
+		ScryfallUri:       printing.ScryfallURI.String(),
+		Uri:               printing.URI.String(),
+		Artist:            ptrToNullString(printing.Artist),
+		ArtistIds:         toJSONString(printing.ArtistIDs),
+		AttractionLights:  toJSONString(printing.AttractionLights),
+		Booster:           printing.Booster,
+		BorderColor:       printing.BorderColor,
+		CardBackID:        printing.CardBackID,
+		CollectorNumber:   printing.CollectorNumber,
+		ContentWarning:    ptrToNullBool(printing.ContentWarning),
+		Digital:           printing.Digital,
+		Finishes:          toJSONStringDirect(printing.Finishes),
+		FlavorName:        ptrToNullString(printing.FlavorName),
+		FlavorText:        ptrToNullString(printing.FlavorText),
+		Foil:              printing.HasFoil(),
+		Nonfoil:           printing.HasNonfoil(),
+		FrameEffects:      toJSONString(printing.FrameEffects),
+		Frame:             printing.Frame,
+		FullArt:           printing.FullArt,
+		Games:             toJSONStringDirect(printing.Games),
+		HighresImage:      printing.HighresImage,
+		IllustrationID:    ptrToNullString(printing.IllustrationID),
+		ImageStatus:       printing.ImageStatus,
+		ImageUris:         toJSONString(printing.ImageURIs),
+		Oversized:         printing.Oversized,
+		Prices:            toJSONStringDirect(printing.Prices),
+		PrintedName:       ptrToNullString(printing.PrintedName),
+		PrintedText:       ptrToNullString(printing.PrintedText),
+		PrintedTypeLine:   ptrToNullString(printing.PrintedTypeLine),
+		Promo:             printing.Promo,
+		PromoTypes:        toJSONString(printing.PromoTypes),
+		PurchaseUris:      toJSONString(printing.PurchaseURIs),
+		Rarity:            printing.Rarity,
+		RelatedUris:       toJSONStringDirect(printing.RelatedURIs),
+		ReleasedAt:        printing.ReleasedAt,
+		Reprint:           printing.Reprint,
+		ScryfallSetUri:    printing.ScryfallSetURI.String(),
+		SetName:           printing.SetName,
+		SetSearchUri:      printing.SetSearchURI.String(),
+		SetType:           printing.SetType,
+		SetUri:            printing.SetURI.String(),
+		Set:               printing.Set,
+		SetID:             printing.SetID,
+		StorySpotlight:    printing.StorySpotlight,
+		Textless:          printing.Textless,
+		Variation:         printing.Variation,
+		VariationOf:       ptrToNullString(printing.VariationOf),
+		SecurityStamp:     ptrToNullString(printing.SecurityStamp),
+		Watermark:         ptrToNullString(printing.Watermark),
+		Preview:           toJSONString(printing.Preview),
+		RawJson:           rawJSONColumn(printing.raw),
+	}
+}
+
 // loadCardsFromDatabase loads cards from database and returns them as []Card with printings grouped
 func (c *Client) loadCardsFromDatabase(db *sql.DB) ([]Card, error) {
 	ctx := context.Background()
@@ -436,91 +1112,184 @@ func (c *Client) loadCardsFromDatabase(db *sql.DB) ([]Card, error) {
 		return nil, fmt.Errorf("error loading cards: %v", err)
 	}
 
-	// Group printings by oracle_id to create unique cards
+	return groupCardPrintings(cardPrintings), nil
+}
+
+// loadCardsPageFromDatabase is loadCardsFromDatabase for limit cards starting at offset
+func (c *Client) loadCardsPageFromDatabase(db *sql.DB, limit, offset int) ([]Card, error) {
+	ctx := context.Background()
+	queries := scryfall.New(db)
+
+	pagedRows, err := queries.GetCardsWithPrintingsPaged(ctx, scryfall.GetCardsWithPrintingsPagedParams{
+		Limit:  int64(limit),
+		Offset: int64(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error loading cards: %v", err)
+	}
+
+	cardPrintings := make([]scryfall.GetCardsWithPrintingsRow, len(pagedRows))
+	for i, row := range pagedRows {
+		cardPrintings[i] = scryfall.GetCardsWithPrintingsRow(row)
+	}
+	return groupCardPrintings(cardPrintings), nil
+}
+
+// groupCardPrintings collapses card+printing rows into one Card per oracle_id
+func groupCardPrintings(cardPrintings []scryfall.GetCardsWithPrintingsRow) []Card {
+	// Group printings by oracle_id to create unique cards, keeping the query's
+	// name, oracle_id order; a map alone would shuffle them on every call
 	cardMap := make(map[string]*Card)
+	var order []string
 
 	for _, row := range cardPrintings {
+		var printingGames []string
+		if row.Games != "" {
+			json.Unmarshal([]byte(row.Games), &printingGames)
+		}
+
 		// Check if we already have this card
 		if existingCard, exists := cardMap[row.OracleID]; exists {
-			// Add this printing's games to the existing card's games
-			if row.Games != "" {
-				var printingGames []string
-				json.Unmarshal([]byte(row.Games), &printingGames)
-
-				// Merge games without duplicates
-				gameSet := make(map[string]bool)
-				for _, game := range existingCard.Games {
-					gameSet[game] = true
-				}
-				for _, game := range printingGames {
-					gameSet[game] = true
-				}
-
-				// Convert back to slice
-				var allGames []string
-				for game := range gameSet {
-					allGames = append(allGames, game)
-				}
-				existingCard.Games = allGames
-			}
-		} else {
-			// Create new card entry
-			card := Card{
-				ID:       row.OracleID, // Use oracle_id as the main ID for the card
-				Name:     row.Name,
-				Layout:   row.Layout,
-				OracleID: &row.OracleID,
-				CMC:      row.Cmc,
-				TypeLine: row.TypeLine,
-			}
+			existingCard.Games = mergeGames(existingCard.Games, printingGames)
+			continue
+		}
 
-			// Handle nullable fields
-			if row.ManaCost.Valid {
-				card.ManaCost = &row.ManaCost.String
-			}
-			if row.OracleText.Valid {
-				card.OracleText = &row.OracleText.String
-			}
+		// Create new card entry
+		card := Card{
+			ID:       row.OracleID, // Use oracle_id as the main ID for the card
+			Name:     row.Name,
+			Layout:   row.Layout,
+			OracleID: &row.OracleID,
+			CMC:      row.Cmc,
+			TypeLine: row.TypeLine,
+			Games:    mergeGames(nil, printingGames),
+		}
 
-			// Parse JSON fields
-			if row.Games != "" {
-				json.Unmarshal([]byte(row.Games), &card.Games)
-			}
-			if row.ColorIdentity != "" {
-				json.Unmarshal([]byte(row.ColorIdentity), &card.ColorIdentity)
-			}
-			if row.Colors.Valid && row.Colors.String != "" {
-				json.Unmarshal([]byte(row.Colors.String), &card.Colors)
-			}
+		// Handle nullable fields
+		if row.ManaCost.Valid {
+			card.ManaCost = &row.ManaCost.String
+		}
+		if row.OracleText.Valid {
+			card.OracleText = &row.OracleText.String
+		}
 
-			cardMap[row.OracleID] = &card
+		// Parse JSON fields
+		if row.ColorIdentity != "" {
+			json.Unmarshal([]byte(row.ColorIdentity), &card.ColorIdentity)
 		}
+		if row.Colors.Valid && row.Colors.String != "" {
+			json.Unmarshal([]byte(row.Colors.String), &card.Colors)
+		}
+
+		cardMap[row.OracleID] = &card
+		order = append(order, row.OracleID)
 	}
 
-	// Convert map to slice
-	var cards []Card
-	for _, card := range cardMap {
-		cards = append(cards, *card)
+	cards := make([]Card, 0, len(order))
+	for _, oracleID := range order {
+		cards = append(cards, *cardMap[oracleID])
+	}
+
+	return cards
+}
+
+// mergeGames returns the sorted union of two game lists without duplicates
+func mergeGames(games, more []string) []string {
+	gameSet := make(map[string]bool)
+	for _, game := range games {
+		gameSet[game] = true
+	}
+	for _, game := range more {
+		gameSet[game] = true
 	}
 
-	return cards, nil
+	merged := make([]string, 0, len(gameSet))
+	for game := range gameSet {
+		merged = append(merged, game)
+	}
+	sort.Strings(merged)
+	return merged
 }
 
-// SearchCardsByQuery searches Scryfall API and returns just the cards (not the List wrapper)
+// SearchCardsByQuery searches Scryfall API and returns the cards from every page of results.
+// A query that matches nothing returns ErrNoCardsFound.
 func (c *Client) SearchCardsByQuery(query string) ([]Card, error) {
-	list, err := c.searchCards(query)
-	if err != nil {
-		return nil, err
+	cards, _, err := c.searchCards(context.Background(), query)
+	return cards, err
+}
+
+// SearchCardsWithWarnings is like SearchCardsByQuery but also returns any warnings
+// Scryfall issued for the query, such as an ignored or malformed filter
+func (c *Client) SearchCardsWithWarnings(query string) ([]Card, []string, error) {
+	return c.searchCards(context.Background(), query)
+}
+
+// ArenaRareQuery is the search FetchFilteredScryfallAPI crawls: cards that are rare or
+// mythic on Arena but have been printed at common or uncommon in paper or on MTGO
+const ArenaRareQuery = "(game:paper game:mtgo -game:arena in:common or in:uncommon) game:arena r>=rare"
+
+// FetchFilteredScryfallAPI crawls ArenaRareQuery and populates the database, skipping
+// cards with any printing rejected by filters. With no filters it applies
+// NotCommonUncommonOnArena, as it always has; use FetchScryfallQuery to crawl other
+// searches or to store every card found.
+func (c *Client) FetchFilteredScryfallAPI(filters ...CardFilter) error {
+	if len(filters) == 0 {
+		filters = []CardFilter{NotCommonUncommonOnArena()}
 	}
-	return list.Data, nil
+	return c.FetchScryfallQuery(ArenaRareQuery, filters...)
 }
 
-// FetchFilteredScryfallAPI fetches filtered cards from Scryfall API and populates the database
-func (c *Client) FetchFilteredScryfallAPI() error {
-	return c.queryAndInsertCards(c.db)
+// FetchScryfallQuery crawls the cards matching query, fetching every printing of each,
+// and stores the cards whose printings all pass filters. With no filters every card
+// found is stored.
+//
+//	client.FetchScryfallQuery("is:reserved", OnReservedList(), InGame(GamePaper))
+func (c *Client) FetchScryfallQuery(query string, filters ...CardFilter) error {
+	return c.FetchScryfallQueryContext(context.Background(), query, filters...)
 }
 
-// GetFilteredCards returns all filtered cards from the database as []Card
+// FetchScryfallQueryContext is FetchScryfallQuery stopping with ctx's error when ctx
+// is done. Cards stored before then are kept.
+func (c *Client) FetchScryfallQueryContext(ctx context.Context, query string, filters ...CardFilter) error {
+	if c.db == nil {
+		return ErrDatabaseDisabled
+	}
+	return c.queryAndInsertCards(ctx, c.db, query, filters...)
+}
+
+// GetFilteredCards returns all filtered cards from the database as []Card, ordered by
+// name and then oracle ID so every call returns them in the same order.
+// Use SortCards for other orders.
 func (c *Client) GetFilteredCards() ([]Card, error) {
+	if c.db == nil {
+		return nil, ErrDatabaseDisabled
+	}
 	return c.loadCardsFromDatabase(c.db)
 }
+
+// GetFilteredCardsPage returns limit cards from the database starting at offset,
+// in the same order as GetFilteredCards. Use CountCards to work out the page count.
+func (c *Client) GetFilteredCardsPage(limit, offset int) ([]Card, error) {
+	if limit <= 0 || offset < 0 {
+		return nil, fmt.Errorf("invalid page: limit %d, offset %d", limit, offset)
+	}
+	if c.db == nil {
+		return nil, ErrDatabaseDisabled
+	}
+	return c.loadCardsPageFromDatabase(c.db, limit, offset)
+}
+
+// CountCards returns how many cards (not printings) are stored in the database.
+// Like GetFilteredCards it skips cards with no stored printings, so it counts the
+// cards GetFilteredCardsPage pages over.
+func (c *Client) CountCards() (int, error) {
+	queries, err := c.queries()
+	if err != nil {
+		return 0, err
+	}
+	count, err := queries.CountCards(context.Background())
+	if err != nil {
+		return 0, fmt.Errorf("error counting cards: %v", err)
+	}
+	return int(count), nil
+}
@@ -0,0 +1,634 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ninesl/scryfall-api/scryfall"
+)
+
+// newTestClient returns a Client with the database disabled whose API requests go to handler
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	options := DefaultClientOptions
+	options.APIURL = server.URL
+	options.UserAgent = "ScryfallTest/1.0"
+	options.DisableDB = true
+	options.TrustURIHost = true
+	options.MaxRetries = 0
+	client, err := NewClientWithOptions(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// fixtureCards decodes the cards of a List fixture in scryfalltest/testdata
+func fixtureCards(t *testing.T, name string) []Card {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("scryfalltest", "testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var list List
+	if err := json.Unmarshal(data, &list); err != nil {
+		t.Fatalf("decoding %s: %v", name, err)
+	}
+	return list.Data
+}
+
+// identifierKey names the card the collection test server returns for id
+func identifierKey(id CardIdentifier) string {
+	return fmt.Sprintf("%+v", id)
+}
+
+// collectionServer answers /cards/collection with one card per identifier, named by
+// identifierKey, except identifiers named "missing", which come back in not_found.
+// Every request body is recorded, and requests numbered in failOn answer 500.
+type collectionServer struct {
+	mu     sync.Mutex
+	chunks [][]CardIdentifier
+	failOn map[int]bool
+}
+
+func (s *collectionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" || r.URL.Path != "/cards/collection" {
+		http.Error(w, "unexpected request "+r.Method+" "+r.URL.Path, http.StatusBadRequest)
+		return
+	}
+	var body struct {
+		Identifiers []CardIdentifier `json:"identifiers"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.chunks = append(s.chunks, body.Identifiers)
+	request := len(s.chunks)
+	s.mu.Unlock()
+	if s.failOn[request] {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"object":"error","code":"internal_error","details":"chunk failed"}`))
+		return
+	}
+
+	list := List{Object: "list", Data: []Card{}}
+	for _, id := range body.Identifiers {
+		if id.Name == "missing" {
+			list.NotFound = append(list.NotFound, id)
+			continue
+		}
+		list.Data = append(list.Data, Card{Object: "card", Name: identifierKey(id)})
+	}
+	json.NewEncoder(w).Encode(list)
+}
+
+// idsByName returns n name identifiers, with the ones at the missing indexes named "missing"
+func idsByName(n int, missing ...int) []CardIdentifier {
+	ids := make([]CardIdentifier, n)
+	for i := range ids {
+		ids[i] = CardIdentifier{Name: fmt.Sprintf("card %d", i)}
+	}
+	for _, i := range missing {
+		ids[i] = CardIdentifier{Name: "missing", Set: fmt.Sprintf("s%d", i)}
+	}
+	return ids
+}
+
+func TestGetCollectionChunks(t *testing.T) {
+	mixed := []CardIdentifier{
+		{ID: "00000000-0000-4000-8000-000000000001"},
+		{MTGOID: 12345},
+		{MultiverseID: 409574},
+		{OracleID: "00000000-0000-4000-8000-0000000000a1"},
+		{IllustrationID: "00000000-0000-4000-8000-0000000000b1"},
+		{Name: "Lightning Bolt"},
+		{Name: "Lightning Bolt", Set: "sta"},
+		{Set: "isd", CollectorNumber: "51"},
+		{Name: "missing", Set: "zzz"},
+	}
+
+	tests := []struct {
+		name        string
+		identifiers []CardIdentifier
+		chunkSizes  []int
+		notFound    int
+	}{
+		{"exactly one chunk", idsByName(75), []int{75}, 0},
+		{"one over a chunk", idsByName(76), []int{75, 1}, 0},
+		{"not found in both chunks", idsByName(80, 3, 78), []int{75, 5}, 2},
+		{"mixed identifier types", mixed, []int{len(mixed)}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &collectionServer{}
+			client := newTestClient(t, server)
+
+			list, notFound, err := client.getCollection(context.Background(), tt.identifiers)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(server.chunks) != len(tt.chunkSizes) {
+				t.Fatalf("made %d POSTs, want %d", len(server.chunks), len(tt.chunkSizes))
+			}
+			start := 0
+			for i, size := range tt.chunkSizes {
+				want := tt.identifiers[start : start+size]
+				if !reflect.DeepEqual(server.chunks[i], want) {
+					t.Errorf("chunk %d sent %v, want %v", i+1, server.chunks[i], want)
+				}
+				start += size
+			}
+
+			var wantCards []string
+			var wantNotFound []CardIdentifier
+			for _, id := range tt.identifiers {
+				if id.Name == "missing" {
+					wantNotFound = append(wantNotFound, id)
+				} else {
+					wantCards = append(wantCards, identifierKey(id))
+				}
+			}
+			var gotCards []string
+			for _, card := range list.Data {
+				gotCards = append(gotCards, card.Name)
+			}
+			if !reflect.DeepEqual(gotCards, wantCards) {
+				t.Errorf("cards = %v, want %v", gotCards, wantCards)
+			}
+			if len(notFound) != tt.notFound || !reflect.DeepEqual(notFound, wantNotFound) {
+				t.Errorf("not found = %v, want %v", notFound, wantNotFound)
+			}
+			if !reflect.DeepEqual(list.NotFound, notFound) {
+				t.Errorf("List.NotFound = %v, want %v", list.NotFound, notFound)
+			}
+			if list.TotalCards != len(wantCards) {
+				t.Errorf("TotalCards = %d, want %d", list.TotalCards, len(wantCards))
+			}
+		})
+	}
+}
+
+func TestGetCollectionPartialOnError(t *testing.T) {
+	server := &collectionServer{failOn: map[int]bool{2: true}}
+	client := newTestClient(t, server)
+
+	identifiers := idsByName(100, 10)
+	list, notFound, err := client.getCollection(context.Background(), identifiers)
+	if err == nil {
+		t.Fatal("expected an error from the failed second chunk")
+	}
+	if !strings.Contains(err.Error(), "76-100") {
+		t.Errorf("error %q doesn't name the failed chunk", err)
+	}
+	if len(server.chunks) != 2 {
+		t.Errorf("made %d POSTs, want 2", len(server.chunks))
+	}
+	if len(list.Data) != 74 {
+		t.Errorf("returned %d cards, want the first chunk's 74", len(list.Data))
+	}
+	if len(notFound) != 1 || notFound[0] != identifiers[10] {
+		t.Errorf("not found = %v, want the first chunk's %v", notFound, identifiers[10])
+	}
+}
+
+// crawlServer answers the crawl's search with results, and each card's printings
+// search (q=oracleid:...) with the printings stored under that oracle id
+type crawlServer struct {
+	mu          sync.Mutex
+	results     []Card
+	printings   map[string][]Card
+	query       string   // the crawl's search query
+	printingsOf []string // oracle ids whose printings were requested
+}
+
+func (s *crawlServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/cards/search" {
+		http.NotFound(w, r)
+		return
+	}
+	cards := s.results
+	if oracleID, ok := strings.CutPrefix(r.URL.Query().Get("q"), "oracleid:"); ok {
+		s.mu.Lock()
+		s.printingsOf = append(s.printingsOf, oracleID)
+		s.mu.Unlock()
+		cards = s.printings[oracleID]
+	} else {
+		s.mu.Lock()
+		s.query = r.URL.Query().Get("q")
+		s.mu.Unlock()
+	}
+	json.NewEncoder(w).Encode(List{Object: "list", TotalCards: len(cards), Data: cards})
+}
+
+// printsSearchURI returns the prints_search_uri Scryfall gives cards with oracleID
+func printsSearchURI(oracleID string) LazyURL {
+	return NewLazyURL("https://api.scryfall.com/cards/search?order=released&q=oracleid%3A" + oracleID + "&unique=prints")
+}
+
+// newCrawlClient returns a Client with a database in a temp working directory whose
+// API requests go to handler
+func newCrawlClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	// the client opens scryfall.db in the working directory
+	t.Chdir(t.TempDir())
+	options := DefaultClientOptions
+	options.APIURL = server.URL
+	options.UserAgent = "ScryfallTest/1.0"
+	options.TrustURIHost = true
+	client, err := NewClientWithOptions(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.db.Close() })
+	return client
+}
+
+// storedCards returns the name of every card in the client's database by oracle id
+func storedCards(t *testing.T, client *Client) map[string]string {
+	t.Helper()
+	stored := make(map[string]string)
+	rows, err := client.db.Query(`SELECT oracle_id, name FROM cards`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var oracleID, name string
+		if err := rows.Scan(&oracleID, &name); err != nil {
+			t.Fatal(err)
+		}
+		stored[oracleID] = name
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return stored
+}
+
+// The crawl used to dereference OracleID unconditionally, panicking on reversible
+// cards (oracle_id only on their faces) and on extras with no oracle_id at all
+func TestCrawlCardsWithoutOracleID(t *testing.T) {
+	token := fixtureCards(t, saprolingFixture)[0]
+	tokenOracleID := *token.OracleID
+	token.PrintsSearchURI = printsSearchURI(tokenOracleID)
+
+	// an extra Scryfall publishes without any oracle_id
+	orphan := token
+	orphan.ID = "00000000-0000-4000-8000-000000000004"
+	orphan.Name = "Oracle-less Saproling"
+	orphan.OracleID = nil
+	orphan.PrintsSearchURI = printsSearchURI("none")
+
+	// a reversible_card: both faces carry the oracle_id, the card itself has none
+	reversible := fixtureCards(t, delverFixture)[0]
+	reversibleOracleID := *reversible.OracleID
+	reversible.ID = "00000000-0000-4000-8000-000000000005"
+	reversible.Name = "Delver of Secrets // Delver of Secrets"
+	reversible.Layout = string(LayoutReversibleCard)
+	reversible.OracleID = nil
+	reversible.CardFaces = append([]CardFace(nil), reversible.CardFaces...)
+	for i := range reversible.CardFaces {
+		reversible.CardFaces[i].OracleID = &reversibleOracleID
+	}
+	reversible.PrintsSearchURI = printsSearchURI(reversibleOracleID)
+
+	server := &crawlServer{
+		results: []Card{orphan, reversible, token},
+		printings: map[string][]Card{
+			tokenOracleID:      {token},
+			reversibleOracleID: {reversible},
+		},
+	}
+	client := newCrawlClient(t, server)
+
+	if err := client.FetchFilteredScryfallAPI(); err != nil {
+		t.Fatal(err)
+	}
+
+	stored := storedCards(t, client)
+	want := map[string]string{
+		reversibleOracleID: reversible.Name,
+		tokenOracleID:      token.Name,
+	}
+	if !reflect.DeepEqual(stored, want) {
+		t.Errorf("stored cards %v, want %v", stored, want)
+	}
+
+	var printingOracleID string
+	err := client.db.QueryRow(`SELECT oracle_id FROM printings WHERE id = ?`, reversible.ID).Scan(&printingOracleID)
+	if err != nil || printingOracleID != reversibleOracleID {
+		t.Errorf("reversible printing stored with oracle_id %q, %v; want %q", printingOracleID, err, reversibleOracleID)
+	}
+	for _, oracleID := range server.printingsOf {
+		if oracleID == "none" {
+			t.Error("the crawl fetched printings for the card without an oracle_id")
+		}
+	}
+}
+
+func TestFetchScryfallQuery(t *testing.T) {
+	token := fixtureCards(t, saprolingFixture)[0]
+	tokenOracleID := *token.OracleID
+	token.PrintsSearchURI = printsSearchURI(tokenOracleID)
+
+	// a card that has been an uncommon on Arena, which the default filter rejects
+	arenaUncommon := fixtureCards(t, fireIceFixture)[0]
+	arenaOracleID := *arenaUncommon.OracleID
+	arenaUncommon.Games = []string{"paper", "arena"}
+	arenaUncommon.Rarity = "uncommon"
+	arenaUncommon.PrintsSearchURI = printsSearchURI(arenaOracleID)
+
+	newServer := func() *crawlServer {
+		return &crawlServer{
+			results: []Card{arenaUncommon, token},
+			printings: map[string][]Card{
+				tokenOracleID: {token},
+				arenaOracleID: {arenaUncommon},
+			},
+		}
+	}
+
+	t.Run("default query and filter", func(t *testing.T) {
+		server := newServer()
+		client := newCrawlClient(t, server)
+		if err := client.FetchFilteredScryfallAPI(); err != nil {
+			t.Fatal(err)
+		}
+		if server.query != ArenaRareQuery {
+			t.Errorf("searched %q, want ArenaRareQuery", server.query)
+		}
+		want := map[string]string{tokenOracleID: token.Name}
+		if got := storedCards(t, client); !reflect.DeepEqual(got, want) {
+			t.Errorf("stored cards %v, want %v", got, want)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		server := newServer()
+		client := newCrawlClient(t, server)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := client.FetchScryfallQueryContext(ctx, "set:apc"); !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want context.Canceled", err)
+		}
+		if server.query != "" || len(server.printingsOf) != 0 {
+			t.Errorf("crawl made requests with a canceled ctx")
+		}
+	})
+
+	t.Run("caller query without filters", func(t *testing.T) {
+		server := newServer()
+		client := newCrawlClient(t, server)
+		if err := client.FetchScryfallQuery("set:apc"); err != nil {
+			t.Fatal(err)
+		}
+		if server.query != "set:apc" {
+			t.Errorf("searched %q, want set:apc", server.query)
+		}
+		want := map[string]string{
+			tokenOracleID: token.Name,
+			arenaOracleID: arenaUncommon.Name,
+		}
+		if got := storedCards(t, client); !reflect.DeepEqual(got, want) {
+			t.Errorf("stored cards %v, want %v", got, want)
+		}
+	})
+}
+
+// Pages and the count must cover the same cards: those with at least one printing
+func TestGetFilteredCardsPageSkipsCardsWithoutPrintings(t *testing.T) {
+	client := newCrawlClient(t, http.NotFoundHandler())
+	ctx := context.Background()
+	queries := scryfall.New(client.db)
+
+	var want []string
+	for i, name := range []string{"Alpha", "Bravo", "Charlie", "Delta", "Echo", "Foxtrot"} {
+		oracleID := fmt.Sprintf("00000000-0000-4000-8000-0000000000a%d", i)
+		if err := queries.UpsertCard(ctx, scryfall.UpsertCardParams{OracleID: oracleID, Name: name}); err != nil {
+			t.Fatal(err)
+		}
+		// every other card is stored without printings
+		if i%2 == 1 {
+			continue
+		}
+		want = append(want, name)
+		printing := scryfall.UpsertPrintingParams{
+			ID:       fmt.Sprintf("00000000-0000-4000-8000-00000000000%d", i),
+			OracleID: oracleID,
+			Games:    `["paper"]`,
+		}
+		if err := queries.UpsertPrinting(ctx, printing); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	count, err := client.CountCards()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != len(want) {
+		t.Errorf("CountCards = %d, want %d", count, len(want))
+	}
+
+	var got []string
+	for offset := 0; offset < count; offset += 2 {
+		page, err := client.GetFilteredCardsPage(2, offset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) != 2 && offset+2 <= count {
+			t.Errorf("page at offset %d has %d cards, want 2", offset, len(page))
+		}
+		got = append(got, cardNames(page)...)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("paged cards %v, want %v", got, want)
+	}
+}
+
+func TestDoRequestGzip(t *testing.T) {
+	var gzipped bytes.Buffer
+	zw := gzip.NewWriter(&gzipped)
+	zw.Write([]byte(`{"object":"set","code":"isd"}`))
+	zw.Close()
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		if r.URL.Path == "/sets/isd" {
+			w.Write(gzipped.Bytes())
+			return
+		}
+		// a proxy error page claiming to be gzip
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("<html>Bad Gateway</html>"))
+	}))
+
+	var set Set
+	if err := client.makeRequest("/sets/isd", &set); err != nil || set.Code != "isd" {
+		t.Fatalf("gzip response = %+v, %v", set, err)
+	}
+
+	err := client.makeRequest("/sets/zzz", &set)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusBadGateway {
+		t.Errorf("error = %v, want an *APIError with status 502", err)
+	}
+}
+
+// slowBody answers with headers straight away and then trickles its body out over wait
+func slowBody(wait time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"object":"set",`))
+		w.(http.Flusher).Flush()
+		time.Sleep(wait)
+		w.Write([]byte(`"code":"isd"}`))
+	}
+}
+
+// Timeout must not cut off a download that takes longer than it to read
+func TestDownloadOutlastsTimeout(t *testing.T) {
+	server := httptest.NewServer(slowBody(300 * time.Millisecond))
+	defer server.Close()
+
+	options := DefaultClientOptions
+	options.APIURL = server.URL
+	options.BulkBaseURL = server.URL
+	options.UserAgent = "ScryfallTest/1.0"
+	options.DisableDB = true
+	options.MaxRetries = 0
+	options.Timeout = 100 * time.Millisecond
+	client, err := NewClientWithOptions(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := client.download("https://data.scryfall.io/default-cards/default-cards.json", "*/*", &buf); err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	if buf.String() != `{"object":"set","code":"isd"}` {
+		t.Errorf("downloaded %q", buf.String())
+	}
+
+	// API calls still time out
+	var set Set
+	if err := client.makeRequest("/sets/isd", &set); err == nil {
+		t.Error("API request outlasted Timeout")
+	}
+}
+
+// resetConn drops the connection under an in-flight request without answering it
+func resetConn(t *testing.T, w http.ResponseWriter) {
+	conn, _, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	conn.(*net.TCPConn).SetLinger(0) // close with a RST rather than a FIN
+	conn.Close()
+}
+
+// retryingClient is newTestClient with retries turned on
+func retryingClient(t *testing.T, handler http.Handler) *Client {
+	client := newTestClient(t, handler)
+	client.maxRetries = 2
+	return client
+}
+
+func TestRetryAfterConnectionReset(t *testing.T) {
+	var attempts atomic.Int32
+	client := retryingClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			resetConn(t, w)
+			return
+		}
+		w.Write([]byte(`{"object":"set","code":"isd"}`))
+	}))
+
+	var set Set
+	if err := client.makeRequest("/sets/isd", &set); err != nil {
+		t.Fatal(err)
+	}
+	if set.Code != "isd" || attempts.Load() != 2 {
+		t.Errorf("got set %q after %d attempts, want isd after 2", set.Code, attempts.Load())
+	}
+}
+
+func TestRetrySkipsCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var attempts atomic.Int32
+	client := retryingClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		cancel()
+		resetConn(t, w)
+	}))
+
+	var set Set
+	err := client.makeRequestContext(ctx, "/sets/isd", &set)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("made %d attempts, want 1", attempts.Load())
+	}
+}
+
+func TestRetryResendsPostBody(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	client := retryingClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		first := len(bodies) == 1
+		mu.Unlock()
+		if first {
+			resetConn(t, w)
+			return
+		}
+		w.Write([]byte(`{"object":"list","data":[{"object":"card","name":"Lightning Bolt"}]}`))
+	}))
+
+	list, _, err := client.getCollection(context.Background(), []CardIdentifier{{Name: "Lightning Bolt"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Data) != 1 || list.Data[0].Name != "Lightning Bolt" {
+		t.Errorf("cards = %v", cardNames(list.Data))
+	}
+	if len(bodies) != 2 || bodies[0] == "" || bodies[0] != bodies[1] {
+		t.Errorf("POST bodies = %q, want the same body sent twice", bodies)
+	}
+}
@@ -0,0 +1,561 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ninesl/scryfall-api/scryfall"
+)
+
+//go:embed schema.sql
+var ddl string
+
+//go:embed migrations/0002_oracle_text_fts.sql
+var oracleTextFTS string
+
+//go:embed migrations/0003_rulings.sql
+var rulingsTable string
+
+//go:embed migrations/0004_printings_raw_json.sql
+var printingsRawJSON string
+
+//go:embed migrations/0005_oracle_text_fts_faces.sql
+var oracleTextFTSFaces string
+
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// migrations are applied in order and recorded in schema_migrations.
+// Version 1 is the baseline schema.sql, which is safe to run against databases
+// created before migrations existed. Append new migrations to the end; never
+// edit or reorder ones that have shipped.
+var migrations = []migration{
+	{version: 1, name: "baseline", sql: ddl},
+	{version: 2, name: "oracle_text_fts", sql: oracleTextFTS},
+	{version: 3, name: "rulings", sql: rulingsTable},
+	{version: 4, name: "printings_raw_json", sql: printingsRawJSON},
+	{version: 5, name: "oracle_text_fts_faces", sql: oracleTextFTSFaces},
+}
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY NOT NULL,
+    name TEXT NOT NULL,
+    applied_at TEXT NOT NULL
+)`
+
+// initSchema brings the database up to date by applying any migrations that haven't run yet
+func initSchema(db *sql.DB) error {
+	if _, err := db.Exec(createMigrationsTable); err != nil {
+		return fmt.Errorf("error creating schema_migrations: %v", err)
+	}
+
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if applied[m.version] {
+			continue
+		}
+		if err := applyMigration(db, m); err != nil {
+			return fmt.Errorf("error applying migration %d (%s): %v", m.version, m.name, err)
+		}
+	}
+	return nil
+}
+
+// appliedMigrations returns the set of migration versions recorded in schema_migrations
+func appliedMigrations(db *sql.DB) (map[int]bool, error) {
+	rows, err := db.Query("SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("error reading schema_migrations: %v", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+// applyMigration runs a single migration and records it in the same transaction
+func applyMigration(db *sql.DB, m migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(m.sql); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(
+		"INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+		m.version, m.name, time.Now().UTC().Format(time.RFC3339),
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ErrDatabaseDisabled is returned by database-backed methods of a client created with DisableDB
+var ErrDatabaseDisabled = errors.New("database disabled: client was created with DisableDB")
+
+// queries returns the sqlc queries for the client's database, or ErrDatabaseDisabled
+func (c *Client) queries() (*scryfall.Queries, error) {
+	if c.db == nil {
+		return nil, ErrDatabaseDisabled
+	}
+	return scryfall.New(c.db), nil
+}
+
+// GetRawCardJSON returns the card object Scryfall sent for the printing with id, as
+// stored by a crawl with ClientOptions.StoreRawJSON set
+func (c *Client) GetRawCardJSON(id string) ([]byte, error) {
+	queries, err := c.queries()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := queries.GetPrintingRawJSON(context.Background(), id)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("printing %s is not in the database", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error loading raw JSON for %s: %v", id, err)
+	}
+	if !raw.Valid {
+		return nil, fmt.Errorf("no raw JSON stored for printing %s", id)
+	}
+	return []byte(raw.String), nil
+}
+
+// WithTx runs fn inside a database transaction, committing if fn returns nil and
+// rolling back otherwise, so a multi-statement import either fully lands or not at all
+func (c *Client) WithTx(ctx context.Context, fn func(q *scryfall.Queries) error) error {
+	queries, err := c.queries()
+	if err != nil {
+		return err
+	}
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(queries.WithTx(tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// jsonColumn pairs a stored JSON column with the Card field it decodes into
+type jsonColumn struct {
+	name   string
+	data   sql.NullString
+	target interface{}
+}
+
+// decodeJSONColumns unmarshals every non-empty column into its target
+func decodeJSONColumns(columns []jsonColumn) error {
+	for _, col := range columns {
+		if !col.data.Valid || col.data.String == "" {
+			continue
+		}
+		if err := json.Unmarshal([]byte(col.data.String), col.target); err != nil {
+			return fmt.Errorf("error decoding %s: %v", col.name, err)
+		}
+	}
+	return nil
+}
+
+// applyCardRow fills the oracle-level (gameplay) fields of card from a cards row
+func applyCardRow(card *Card, row scryfall.Card) error {
+	oracleID := row.OracleID
+	card.OracleID = &oracleID
+	card.Name = row.Name
+	card.Layout = row.Layout
+	card.PrintsSearchURI = NewLazyURL(row.PrintsSearchUri)
+	card.RulingsURI = NewLazyURL(row.RulingsUri)
+	card.CMC = row.Cmc
+	card.Defense = nullStringToPtr(row.Defense)
+	card.EDHRecRank = nullInt64ToPtr(row.EdhrecRank)
+	card.GameChanger = nullBoolToPtr(row.GameChanger)
+	card.HandModifier = nullStringToPtr(row.HandModifier)
+	card.LifeModifier = nullStringToPtr(row.LifeModifier)
+	card.Loyalty = nullStringToPtr(row.Loyalty)
+	card.ManaCost = nullStringToPtr(row.ManaCost)
+	card.OracleText = nullStringToPtr(row.OracleText)
+	card.PennyRank = nullInt64ToPtr(row.PennyRank)
+	card.Power = nullStringToPtr(row.Power)
+	card.Reserved = row.Reserved
+	card.Toughness = nullStringToPtr(row.Toughness)
+	card.TypeLine = row.TypeLine
+
+	return decodeJSONColumns([]jsonColumn{
+		{"all_parts", row.AllParts, &card.AllParts},
+		{"card_faces", row.CardFaces, &card.CardFaces},
+		{"color_identity", stringToNullString(row.ColorIdentity), &card.ColorIdentity},
+		{"color_indicator", row.ColorIndicator, &card.ColorIndicator},
+		{"colors", row.Colors, &card.Colors},
+		{"keywords", stringToNullString(row.Keywords), &card.Keywords},
+		{"legalities", stringToNullString(row.Legalities), &card.Legalities},
+		{"produced_mana", row.ProducedMana, &card.ProducedMana},
+	})
+}
+
+// applyPrintingRow fills the printing-level fields of card from a printings row
+func applyPrintingRow(card *Card, row scryfall.Printing) error {
+	oracleID := row.OracleID
+	card.ID = row.ID
+	card.OracleID = &oracleID
+	card.ArenaID = nullInt64ToPtr(row.ArenaID)
+	card.Lang = row.Lang
+	card.MTGOID = nullInt64ToPtr(row.MtgoID)
+	card.MTGOFoilID = nullInt64ToPtr(row.MtgoFoilID)
+	card.TCGPlayerID = nullInt64ToPtr(row.TcgplayerID)
+	card.TCGPlayerEtchedID = nullInt64ToPtr(row.TcgplayerEtchedID)
+	card.CardmarketID = nullInt64ToPtr(row.CardmarketID)
+	card.Object = row.Object
+	card.ScryfallURI = NewLazyURL(row.ScryfallUri)
+	card.URI = NewLazyURL(row.Uri)
+	card.Artist = nullStringToPtr(row.Artist)
+	card.Booster = row.Booster
+	card.BorderColor = row.BorderColor
+	card.CardBackID = row.CardBackID
+	card.CollectorNumber = row.CollectorNumber
+	card.ContentWarning = nullBoolToPtr(row.ContentWarning)
+	card.Digital = row.Digital
+	card.FlavorName = nullStringToPtr(row.FlavorName)
+	card.FlavorText = nullStringToPtr(row.FlavorText)
+	card.Frame = row.Frame
+	card.FullArt = row.FullArt
+	card.HighresImage = row.HighresImage
+	card.IllustrationID = nullStringToPtr(row.IllustrationID)
+	card.ImageStatus = row.ImageStatus
+	card.Oversized = row.Oversized
+	card.PrintedName = nullStringToPtr(row.PrintedName)
+	card.PrintedText = nullStringToPtr(row.PrintedText)
+	card.PrintedTypeLine = nullStringToPtr(row.PrintedTypeLine)
+	card.Promo = row.Promo
+	card.Rarity = row.Rarity
+	card.ReleasedAt = row.ReleasedAt
+	card.Reprint = row.Reprint
+	card.ScryfallSetURI = NewLazyURL(row.ScryfallSetUri)
+	card.SetName = row.SetName
+	card.SetSearchURI = NewLazyURL(row.SetSearchUri)
+	card.SetType = row.SetType
+	card.SetURI = NewLazyURL(row.SetUri)
+	card.Set = row.Set
+	card.SetID = row.SetID
+	card.StorySpotlight = row.StorySpotlight
+	card.Textless = row.Textless
+	card.Variation = row.Variation
+	card.VariationOf = nullStringToPtr(row.VariationOf)
+	card.SecurityStamp = nullStringToPtr(row.SecurityStamp)
+	card.Watermark = nullStringToPtr(row.Watermark)
+
+	return decodeJSONColumns([]jsonColumn{
+		{"multiverse_ids", row.MultiverseIds, &card.MultiverseIDs},
+		{"artist_ids", row.ArtistIds, &card.ArtistIDs},
+		{"attraction_lights", row.AttractionLights, &card.AttractionLights},
+		{"finishes", stringToNullString(row.Finishes), &card.Finishes},
+		{"frame_effects", row.FrameEffects, &card.FrameEffects},
+		{"games", stringToNullString(row.Games), &card.Games},
+		{"image_uris", row.ImageUris, &card.ImageURIs},
+		{"prices", stringToNullString(row.Prices), &card.Prices},
+		{"promo_types", row.PromoTypes, &card.PromoTypes},
+		{"purchase_uris", row.PurchaseUris, &card.PurchaseURIs},
+		{"related_uris", stringToNullString(row.RelatedUris), &card.RelatedURIs},
+		{"preview", row.Preview, &card.Preview},
+	})
+}
+
+// GetOracleCards returns one Card per oracle_id from the database with only
+// the oracle-level (gameplay) fields set, like Scryfall's oracle_cards view
+func (c *Client) GetOracleCards(ctx context.Context) ([]Card, error) {
+	queries, err := c.queries()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := queries.GetOracleCards(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error loading oracle cards: %v", err)
+	}
+
+	cards := make([]Card, 0, len(rows))
+	for _, row := range rows {
+		var card Card
+		if err := applyCardRow(&card, row); err != nil {
+			return nil, fmt.Errorf("error loading card %s: %v", row.Name, err)
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+// GetPrintings returns every stored printing of the card with oracleID, newest first.
+// Each Card carries both the printing fields and the shared oracle-level fields.
+func (c *Client) GetPrintings(ctx context.Context, oracleID string) ([]Card, error) {
+	queries, err := c.queries()
+	if err != nil {
+		return nil, err
+	}
+
+	oracle, err := queries.GetCard(ctx, oracleID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading card %s: %v", oracleID, err)
+	}
+
+	rows, err := queries.GetPrintingsByOracleID(ctx, oracleID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading printings for %s: %v", oracle.Name, err)
+	}
+
+	printings := make([]Card, 0, len(rows))
+	for _, row := range rows {
+		var card Card
+		if err := applyCardRow(&card, oracle); err != nil {
+			return nil, fmt.Errorf("error loading card %s: %v", oracle.Name, err)
+		}
+		if err := applyPrintingRow(&card, row); err != nil {
+			return nil, fmt.Errorf("error loading printing %s: %v", row.ID, err)
+		}
+		printings = append(printings, card)
+	}
+	return printings, nil
+}
+
+// CardsByArtistLocal returns every stored printing illustrated by artist, matched
+// case-insensitively, newest first. Different artists can share a name; use
+// CardsByArtistIDLocal with a Card.ArtistIDs entry to tell them apart.
+func (c *Client) CardsByArtistLocal(artist string) ([]Card, error) {
+	ctx := context.Background()
+	queries, err := c.queries()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := queries.GetCardsByArtist(ctx, artist)
+	if err != nil {
+		return nil, fmt.Errorf("error loading cards by %s: %v", artist, err)
+	}
+	return c.cardsFromPrintings(ctx, rows)
+}
+
+// CardsByArtistIDLocal returns every stored printing credited to the artist with
+// Scryfall id artistID, newest first
+func (c *Client) CardsByArtistIDLocal(artistID string) ([]Card, error) {
+	ctx := context.Background()
+	queries, err := c.queries()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := queries.GetCardsByArtistID(ctx, artistID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading cards by artist %s: %v", artistID, err)
+	}
+	return c.cardsFromPrintings(ctx, rows)
+}
+
+// cardsFromPrintings combines each printing row with its card's oracle-level fields
+func (c *Client) cardsFromPrintings(ctx context.Context, rows []scryfall.Printing) ([]Card, error) {
+	queries, err := c.queries()
+	if err != nil {
+		return nil, err
+	}
+	oracles := make(map[string]scryfall.Card)
+
+	cards := make([]Card, 0, len(rows))
+	for _, row := range rows {
+		oracle, ok := oracles[row.OracleID]
+		if !ok {
+			var err error
+			oracle, err = queries.GetCard(ctx, row.OracleID)
+			if err != nil {
+				return nil, fmt.Errorf("error loading card %s: %v", row.OracleID, err)
+			}
+			oracles[row.OracleID] = oracle
+		}
+
+		var card Card
+		if err := applyCardRow(&card, oracle); err != nil {
+			return nil, fmt.Errorf("error loading card %s: %v", oracle.Name, err)
+		}
+		if err := applyPrintingRow(&card, row); err != nil {
+			return nil, fmt.Errorf("error loading printing %s: %v", row.ID, err)
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+// SearchLocalOracleText returns stored cards whose oracle text contains the phrase text,
+// best matches first. Matching is by whole words and ignores case and punctuation, so
+// "draw a card" also finds "Draw a card." The text of each face of a multi-face card
+// is searched too.
+func (c *Client) SearchLocalOracleText(text string) ([]Card, error) {
+	queries, err := c.queries()
+	if err != nil {
+		return nil, err
+	}
+
+	match := `oracle_text:"` + strings.ReplaceAll(text, `"`, `""`) + `"`
+	rows, err := queries.SearchOracleText(context.Background(), match)
+	if err != nil {
+		return nil, fmt.Errorf("error searching oracle text for %q: %v", text, err)
+	}
+
+	cards := make([]Card, 0, len(rows))
+	for _, row := range rows {
+		var card Card
+		if err := applyCardRow(&card, row); err != nil {
+			return nil, fmt.Errorf("error loading card %s: %v", row.Name, err)
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+// CardsWithKeyword returns the stored cards that have keyword ("Flying", "Ward", ...),
+// compared case-insensitively. Like SearchLocalOracleText, only oracle-level
+// fields are filled in.
+func (c *Client) CardsWithKeyword(keyword string) ([]Card, error) {
+	queries, err := c.queries()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := queries.GetCardsByKeyword(context.Background(), keyword)
+	if err != nil {
+		return nil, fmt.Errorf("error loading cards with keyword %q: %v", keyword, err)
+	}
+
+	cards := make([]Card, 0, len(rows))
+	for _, row := range rows {
+		var card Card
+		if err := applyCardRow(&card, row); err != nil {
+			return nil, fmt.Errorf("error loading card %s: %v", row.Name, err)
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+// maxLocalNameMatches caps how many cards FindLocalByName returns
+const maxLocalNameMatches = 10
+
+// FindLocalByName looks up stored cards by a possibly misspelled name, best match first.
+// Names containing name (ignoring case) are tried first; when there are none, every
+// stored name within a few typos of name is returned instead. A blank name is an error.
+func (c *Client) FindLocalByName(name string) ([]Card, error) {
+	ctx := context.Background()
+	queries, err := c.queries()
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("empty card name")
+	}
+	pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(name) + "%"
+	rows, err := queries.FindCardsByName(ctx, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("error searching for %q: %v", name, err)
+	}
+
+	// no substring match, so fall back to typo-tolerant matching over every name
+	maxDistance := 0
+	if len(rows) == 0 {
+		if rows, err = queries.GetOracleCards(ctx); err != nil {
+			return nil, fmt.Errorf("error searching for %q: %v", name, err)
+		}
+		maxDistance = len([]rune(name))/4 + 1
+	}
+
+	type match struct {
+		row      scryfall.Card
+		distance int
+	}
+	target := strings.ToLower(name)
+	var matches []match
+	for _, row := range rows {
+		distance := nameDistance(target, strings.ToLower(row.Name))
+		if maxDistance > 0 && distance > maxDistance {
+			continue
+		}
+		matches = append(matches, match{row, distance})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+	if len(matches) > maxLocalNameMatches {
+		matches = matches[:maxLocalNameMatches]
+	}
+
+	cards := make([]Card, 0, len(matches))
+	for _, m := range matches {
+		var card Card
+		if err := applyCardRow(&card, m.row); err != nil {
+			return nil, fmt.Errorf("error loading card %s: %v", m.row.Name, err)
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+// nameDistance returns the edit distance between target and name, or between target
+// and the closest face of a multi-face name ("Fire // Ice"), so a misspelled front
+// face still matches the full name
+func nameDistance(target, name string) int {
+	distance := levenshtein(target, name)
+	if !strings.Contains(name, " // ") {
+		return distance
+	}
+	for _, face := range strings.Split(name, " // ") {
+		distance = min(distance, levenshtein(target, face))
+	}
+	return distance
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/ninesl/scryfall-api/scryfall"
+)
+
+func TestFindLocalByName(t *testing.T) {
+	client := newCrawlClient(t, http.NotFoundHandler())
+	queries := scryfall.New(client.db)
+	for i, name := range []string{
+		"Delver of Secrets // Insectile Aberration",
+		"Fire // Ice",
+		"Lightning Bolt",
+		"Lightning Helix",
+	} {
+		card := scryfall.UpsertCardParams{OracleID: fmt.Sprintf("00000000-0000-4000-8000-0000000000a%d", i), Name: name}
+		if err := queries.UpsertCard(context.Background(), card); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"substring", "lightning", []string{"Lightning Bolt", "Lightning Helix"}},
+		{"typo", "lightnin bolt", []string{"Lightning Bolt"}},
+		{"typo in front face", "delvr of secrets", []string{"Delver of Secrets // Insectile Aberration"}},
+		{"typo in back face", "insectile aberation", []string{"Delver of Secrets // Insectile Aberration"}},
+		{"typo in short face", "fier", []string{"Fire // Ice"}},
+		{"no match", "tarmogoyf", nil},
+	}
+	for _, blank := range []string{"", "   "} {
+		if cards, err := client.FindLocalByName(blank); err == nil {
+			t.Errorf("FindLocalByName(%q) = %v, want an error", blank, cardNames(cards))
+		}
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cards, err := client.FindLocalByName(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := cardNames(cards); len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+				t.Errorf("FindLocalByName(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchLocalOracleText(t *testing.T) {
+	client := newCrawlClient(t, http.NotFoundHandler())
+	ctx := context.Background()
+	queries := scryfall.New(client.db)
+
+	upsert := func(oracleID, name, text, faces string) {
+		t.Helper()
+		card := scryfall.UpsertCardParams{
+			OracleID:   oracleID,
+			Name:       name,
+			OracleText: sql.NullString{String: text, Valid: text != ""},
+			CardFaces:  sql.NullString{String: faces, Valid: faces != ""},
+		}
+		if err := queries.UpsertCard(ctx, card); err != nil {
+			t.Fatal(err)
+		}
+	}
+	search := func(text string) []string {
+		t.Helper()
+		cards, err := client.SearchLocalOracleText(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names := cardNames(cards)
+		sort.Strings(names)
+		return names
+	}
+
+	upsert("00000000-0000-4000-8000-0000000000a1", "Divination", "Draw two cards.", "")
+	upsert("00000000-0000-4000-8000-0000000000a2", "Opt", "Scry 1.\nDraw a card.", "")
+	// multi-face cards keep their text only on the faces
+	upsert("00000000-0000-4000-8000-0000000000a3", "Fire // Ice", "",
+		`[{"name":"Fire","oracle_text":"Fire deals 2 damage divided as you choose among one or two targets."},
+		  {"name":"Ice","oracle_text":"Tap target permanent.\nDraw a card."}]`)
+	upsert("00000000-0000-4000-8000-0000000000a4", "Delver of Secrets // Insectile Aberration", "",
+		`[{"name":"Delver of Secrets","oracle_text":"At the beginning of your upkeep, look at the top card of your library."},
+		  {"name":"Insectile Aberration","oracle_text":"Flying"}]`)
+
+	if got, want := search("draw a card"), []string{"Fire // Ice", "Opt"}; !reflect.DeepEqual(got, want) {
+		t.Errorf(`"draw a card" = %v, want %v`, got, want)
+	}
+	if got, want := search("flying"), []string{"Delver of Secrets // Insectile Aberration"}; !reflect.DeepEqual(got, want) {
+		t.Errorf(`"flying" = %v, want %v`, got, want)
+	}
+
+	// an upsert replaces the card's indexed text, and a delete removes it
+	upsert("00000000-0000-4000-8000-0000000000a2", "Opt", "Scry 1.", "")
+	if _, err := client.db.Exec(`DELETE FROM cards WHERE oracle_id = ?`, "00000000-0000-4000-8000-0000000000a3"); err != nil {
+		t.Fatal(err)
+	}
+	if got := search("draw a card"); len(got) != 0 {
+		t.Errorf(`"draw a card" after update and delete = %v, want none`, got)
+	}
+
+	// the index doesn't depend on cards' rowids, which VACUUM may renumber
+	if _, err := client.db.Exec(`VACUUM`); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := search("draw two cards"), []string{"Divination"}; !reflect.DeepEqual(got, want) {
+		t.Errorf(`"draw two cards" after VACUUM = %v, want %v`, got, want)
+	}
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxCollectionSize is the most identifiers /cards/collection accepts per request
+const maxCollectionSize = 75
+
+// DeckEntry is one parsed line of a text deck list
+type DeckEntry struct {
+	Quantity        int
+	Name            string
+	Set             string // optional set code, e.g. "neo"
+	CollectorNumber string // optional, only meaningful with Set
+	Sideboard       bool
+	Line            int // line number in the source deck list
+}
+
+// ResolvedCard is a DeckEntry matched to a full Card
+type ResolvedCard struct {
+	DeckEntry
+	Card Card
+}
+
+// deckLinePattern matches "4 Lightning Bolt", "4x Lightning Bolt", "1 Island (NEO) 277"
+var deckLinePattern = regexp.MustCompile(`^(?:(\d+)x?\s+)?(.+?)(?:\s+\(([A-Za-z0-9]+)\)(?:\s+(\S+))?)?$`)
+
+// ParseDeckList parses a text deck list, one card per line, in the common
+// "4 Lightning Bolt" or "1 Island (NEO) 277" forms. Blank lines and lines starting
+// with # or // are skipped. A "Sideboard" header or "SB:" prefix marks sideboard cards;
+// "Deck", "Commander" and "Companion" headers are accepted and ignored.
+func ParseDeckList(r io.Reader) ([]DeckEntry, error) {
+	var entries []DeckEntry
+	sideboard := false
+	lineNumber := 0
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		switch strings.ToLower(strings.TrimSuffix(line, ":")) {
+		case "sideboard":
+			sideboard = true
+			continue
+		case "deck", "main", "mainboard", "commander", "companion":
+			sideboard = false
+			continue
+		}
+
+		entrySideboard := sideboard
+		if strings.HasPrefix(strings.ToUpper(line), "SB:") {
+			entrySideboard = true
+			line = strings.TrimSpace(line[len("SB:"):])
+		}
+
+		// Arena and Moxfield exports mark foils with a trailing *F*
+		line = strings.TrimSpace(strings.TrimSuffix(line, "*F*"))
+
+		match := deckLinePattern.FindStringSubmatch(line)
+		if match == nil {
+			return nil, fmt.Errorf("line %d: could not parse %q", lineNumber, line)
+		}
+
+		quantity := 1
+		if match[1] != "" {
+			q, err := strconv.Atoi(match[1])
+			if err != nil || q <= 0 {
+				return nil, fmt.Errorf("line %d: invalid quantity %q", lineNumber, match[1])
+			}
+			quantity = q
+		}
+
+		entries = append(entries, DeckEntry{
+			Quantity:        quantity,
+			Name:            strings.TrimSpace(match[2]),
+			Set:             strings.ToLower(match[3]),
+			CollectorNumber: match[4],
+			Sideboard:       entrySideboard,
+			Line:            lineNumber,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// identifier picks the most specific collection identifier for the entry
+func (e DeckEntry) identifier() CardIdentifier {
+	switch {
+	case e.Set != "" && e.CollectorNumber != "":
+		return CardIdentifier{Set: e.Set, CollectorNumber: e.CollectorNumber}
+	case e.Set != "":
+		return CardIdentifier{Name: e.Name, Set: e.Set}
+	default:
+		return CardIdentifier{Name: e.Name}
+	}
+}
+
+// ResolveDeckList looks up every entry through /cards/collection, 75 at a time.
+// Entries Scryfall couldn't match are returned in the second slice so the
+// caller can report or fix them; err is only set when a request fails.
+func (c *Client) ResolveDeckList(entries []DeckEntry) ([]ResolvedCard, []DeckEntry, error) {
+	var resolved []ResolvedCard
+	var unresolved []DeckEntry
+
+	identifiers := make([]CardIdentifier, len(entries))
+	for i, entry := range entries {
+		identifiers[i] = entry.identifier()
+	}
+
+	list, notFound, err := c.getCollection(context.Background(), identifiers)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error resolving deck list: %v", err)
+	}
+
+	// Cards come back in request order with not-found identifiers left out,
+	// so walk the request and skip each identifier reported as not found.
+	next := 0
+	for i, entry := range entries {
+		if j := indexOfIdentifier(notFound, identifiers[i]); j >= 0 {
+			notFound = append(notFound[:j:j], notFound[j+1:]...)
+			unresolved = append(unresolved, entry)
+			continue
+		}
+		if next >= len(list.Data) {
+			unresolved = append(unresolved, entry)
+			continue
+		}
+		resolved = append(resolved, ResolvedCard{DeckEntry: entry, Card: list.Data[next]})
+		next++
+	}
+
+	return resolved, unresolved, nil
+}
+
+// indexOfIdentifier finds id in identifiers, comparing string fields case-insensitively
+func indexOfIdentifier(identifiers []CardIdentifier, id CardIdentifier) int {
+	for i, candidate := range identifiers {
+		if strings.EqualFold(candidate.ID, id.ID) &&
+			candidate.MTGOID == id.MTGOID &&
+			candidate.MultiverseID == id.MultiverseID &&
+			strings.EqualFold(candidate.OracleID, id.OracleID) &&
+			strings.EqualFold(candidate.IllustrationID, id.IllustrationID) &&
+			strings.EqualFold(candidate.Name, id.Name) &&
+			strings.EqualFold(candidate.Set, id.Set) &&
+			strings.EqualFold(candidate.CollectorNumber, id.CollectorNumber) {
+			return i
+		}
+	}
+	return -1
+}
+
+// DeckFormat selects the layout ExportDeckList writes
+type DeckFormat string
+
+const (
+	DeckFormatPlain DeckFormat = "plain" // "4 Lightning Bolt", the form ParseDeckList reads
+	DeckFormatArena DeckFormat = "arena" // "4 Lightning Bolt (STA) 42", as MTG Arena imports
+	DeckFormatMTGO  DeckFormat = "mtgo"  // the XML .dek file MTGO imports
+)
+
+// ExportDeckList writes a resolved deck to w in format, main deck first and then the
+// sideboard. Arena lines use each card's set and collector number, leaving out any it
+// lacks, and .dek files each card's MTGO ID when it has one. Both name double-faced cards by their front face.
+func ExportDeckList(w io.Writer, entries []ResolvedCard, format DeckFormat) error {
+	var mainDeck, sideboard []ResolvedCard
+	for _, entry := range entries {
+		if entry.Sideboard {
+			sideboard = append(sideboard, entry)
+		} else {
+			mainDeck = append(mainDeck, entry)
+		}
+	}
+
+	// bufio.Writer keeps the first write error, returned by Flush
+	bw := bufio.NewWriter(w)
+	switch format {
+	case DeckFormatPlain:
+		writeDeckLines(bw, mainDeck, sideboard, func(entry ResolvedCard) string {
+			return fmt.Sprintf("%d %s", entry.Quantity, entry.Card.Name)
+		})
+	case DeckFormatArena:
+		bw.WriteString("Deck\n")
+		writeDeckLines(bw, mainDeck, sideboard, func(entry ResolvedCard) string {
+			set, number := entry.Card.Set, entry.Card.CollectorNumber
+			if set == "" {
+				set, number = entry.Set, entry.CollectorNumber
+			}
+			line := fmt.Sprintf("%d %s", entry.Quantity, frontFaceName(&entry.Card))
+			// Arena rejects "()", so a card without a set is named alone
+			if set == "" {
+				return line
+			}
+			if number == "" {
+				return line + " (" + strings.ToUpper(set) + ")"
+			}
+			return line + " (" + strings.ToUpper(set) + ") " + number
+		})
+	case DeckFormatMTGO:
+		if err := writeDek(bw, entries); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown deck format %q", format)
+	}
+	return bw.Flush()
+}
+
+// writeDeckLines writes one line per entry, with the sideboard after a "Sideboard" header
+func writeDeckLines(bw *bufio.Writer, mainDeck, sideboard []ResolvedCard, line func(ResolvedCard) string) {
+	for _, entry := range mainDeck {
+		bw.WriteString(line(entry) + "\n")
+	}
+	if len(sideboard) == 0 {
+		return
+	}
+	bw.WriteString("\nSideboard\n")
+	for _, entry := range sideboard {
+		bw.WriteString(line(entry) + "\n")
+	}
+}
+
+// dekCard is a Cards element of an MTGO .dek file
+type dekCard struct {
+	CatID     int    `xml:"CatID,attr,omitempty"`
+	Quantity  int    `xml:"Quantity,attr"`
+	Sideboard bool   `xml:"Sideboard,attr"`
+	Name      string `xml:"Name,attr"`
+}
+
+// writeDek writes entries as an MTGO .dek XML document
+func writeDek(bw *bufio.Writer, entries []ResolvedCard) error {
+	deck := struct {
+		XMLName              xml.Name  `xml:"Deck"`
+		XSD                  string    `xml:"xmlns:xsd,attr"`
+		XSI                  string    `xml:"xmlns:xsi,attr"`
+		NetDeckID            int       `xml:"NetDeckID"`
+		PreconstructedDeckID int       `xml:"PreconstructedDeckID"`
+		Cards                []dekCard `xml:"Cards"`
+	}{
+		XSD: "http://www.w3.org/2001/XMLSchema",
+		XSI: "http://www.w3.org/2001/XMLSchema-instance",
+	}
+	for _, entry := range entries {
+		card := dekCard{
+			Quantity:  entry.Quantity,
+			Sideboard: entry.Sideboard,
+			Name:      frontFaceName(&entry.Card),
+		}
+		if entry.Card.MTGOID != nil {
+			card.CatID = *entry.Card.MTGOID
+		}
+		deck.Cards = append(deck.Cards, card)
+	}
+
+	bw.WriteString(xml.Header)
+	encoder := xml.NewEncoder(bw)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(deck); err != nil {
+		return fmt.Errorf("error encoding .dek file: %v", err)
+	}
+	bw.WriteString("\n")
+	return nil
+}
+
+// frontFaceName returns the name of a double-faced card's front face, which is how
+// Arena and MTGO name them; other cards keep their full name, "Fire // Ice" included
+func frontFaceName(card *Card) string {
+	if card.IsDoubleFaced() && len(card.CardFaces) > 0 {
+		return card.CardFaces[0].Name
+	}
+	return card.Name
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// ExportDeckList's text formats must read back through ParseDeckList unchanged
+func TestExportDeckListRoundTrip(t *testing.T) {
+	delver := fixtureCards(t, delverFixture)[0]
+	fireIce := fixtureCards(t, fireIceFixture)[0]
+	saproling := fixtureCards(t, saprolingFixture)[0]
+	deck := []ResolvedCard{
+		{DeckEntry: DeckEntry{Quantity: 4, Name: delver.Name}, Card: delver},
+		{DeckEntry: DeckEntry{Quantity: 2, Name: fireIce.Name}, Card: fireIce},
+		{DeckEntry: DeckEntry{Quantity: 3, Name: fireIce.Name, Sideboard: true}, Card: fireIce},
+		{DeckEntry: DeckEntry{Quantity: 1, Name: saproling.Name, Sideboard: true}, Card: saproling},
+	}
+
+	tests := []struct {
+		format DeckFormat
+		want   []DeckEntry
+	}{
+		{DeckFormatPlain, []DeckEntry{
+			{Quantity: 4, Name: "Delver of Secrets // Insectile Aberration"},
+			{Quantity: 2, Name: "Fire // Ice"},
+			{Quantity: 3, Name: "Fire // Ice", Sideboard: true},
+			{Quantity: 1, Name: "Saproling", Sideboard: true},
+		}},
+		{DeckFormatArena, []DeckEntry{
+			{Quantity: 4, Name: "Delver of Secrets", Set: "isd", CollectorNumber: "51"},
+			{Quantity: 2, Name: "Fire // Ice", Set: "apc", CollectorNumber: "128"},
+			{Quantity: 3, Name: "Fire // Ice", Set: "apc", CollectorNumber: "128", Sideboard: true},
+			{Quantity: 1, Name: "Saproling", Set: "tdmu", CollectorNumber: "14", Sideboard: true},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := ExportDeckList(&buf, deck, tt.format); err != nil {
+				t.Fatal(err)
+			}
+			entries, err := ParseDeckList(bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Fatalf("parsing exported deck: %v\n%s", err, buf.String())
+			}
+			for i := range entries {
+				entries[i].Line = 0
+			}
+			if !reflect.DeepEqual(entries, tt.want) {
+				t.Errorf("round trip = %+v, want %+v\nexported:\n%s", entries, tt.want, buf.String())
+			}
+		})
+	}
+}
+
+// A card with no set is written by name alone, without an empty "()" Arena rejects
+func TestExportDeckListArenaWithoutSet(t *testing.T) {
+	deck := []ResolvedCard{
+		{DeckEntry: DeckEntry{Quantity: 4, Name: "Opt"}, Card: Card{Name: "Opt"}},
+		{DeckEntry: DeckEntry{Quantity: 1, Name: "Island", Set: "neo"}, Card: Card{Name: "Island"}},
+	}
+	var buf bytes.Buffer
+	if err := ExportDeckList(&buf, deck, DeckFormatArena); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Deck\n4 Opt\n1 Island (NEO)\n"; buf.String() != want {
+		t.Errorf("exported %q, want %q", buf.String(), want)
+	}
+}
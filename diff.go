@@ -0,0 +1,110 @@
+package main
+
+import "maps"
+
+// DiffField names a part of a card DiffCardsFields compares when deciding whether a
+// card changed
+type DiffField string
+
+const (
+	DiffPrices     DiffField = "prices"
+	DiffLegalities DiffField = "legalities"
+	DiffRarity     DiffField = "rarity"
+	DiffOracleText DiffField = "oracle_text"
+	DiffTypeLine   DiffField = "type_line"
+	DiffManaCost   DiffField = "mana_cost"
+	DiffName       DiffField = "name"
+)
+
+// DefaultDiffFields are the fields DiffCards compares
+var DefaultDiffFields = []DiffField{DiffPrices, DiffLegalities, DiffRarity}
+
+// CardDiff is the result of comparing two lists of cards by ID
+type CardDiff struct {
+	Added   []Card       // in new but not old
+	Removed []Card       // in old but not new
+	Changed []CardChange // in both, with at least one compared field different
+}
+
+// CardChange is a card present in both lists whose compared fields differ
+type CardChange struct {
+	Old    Card
+	New    Card
+	Fields []DiffField // the fields that differ, in the order they were compared
+}
+
+// DiffCards compares old and new by card ID, reporting a card as changed when its
+// prices, legalities or rarity differ
+func DiffCards(old, new []Card) CardDiff {
+	return DiffCardsFields(old, new, DefaultDiffFields...)
+}
+
+// DiffCardsFields is DiffCards comparing only fields. With no fields, cards in both
+// lists are never reported as changed. Added and Changed follow new's order and
+// Removed follows old's.
+func DiffCardsFields(old, new []Card, fields ...DiffField) CardDiff {
+	oldByID := make(map[string]*Card, len(old))
+	for i := range old {
+		oldByID[old[i].ID] = &old[i]
+	}
+	newIDs := make(map[string]bool, len(new))
+
+	var diff CardDiff
+	for i := range new {
+		card := &new[i]
+		newIDs[card.ID] = true
+
+		before, ok := oldByID[card.ID]
+		if !ok {
+			diff.Added = append(diff.Added, *card)
+			continue
+		}
+
+		var changed []DiffField
+		for _, field := range fields {
+			if !fieldEqual(before, card, field) {
+				changed = append(changed, field)
+			}
+		}
+		if len(changed) > 0 {
+			diff.Changed = append(diff.Changed, CardChange{Old: *before, New: *card, Fields: changed})
+		}
+	}
+
+	for _, card := range old {
+		if !newIDs[card.ID] {
+			diff.Removed = append(diff.Removed, card)
+		}
+	}
+	return diff
+}
+
+// fieldEqual reports whether a and b have the same value for field. Unknown fields
+// compare equal.
+func fieldEqual(a, b *Card, field DiffField) bool {
+	switch field {
+	case DiffPrices:
+		return maps.EqualFunc(a.Prices, b.Prices, stringPtrEqual)
+	case DiffLegalities:
+		return maps.Equal(a.Legalities, b.Legalities)
+	case DiffRarity:
+		return a.Rarity == b.Rarity
+	case DiffOracleText:
+		return stringPtrEqual(a.OracleText, b.OracleText)
+	case DiffTypeLine:
+		return a.TypeLine == b.TypeLine
+	case DiffManaCost:
+		return stringPtrEqual(a.ManaCost, b.ManaCost)
+	case DiffName:
+		return a.Name == b.Name
+	}
+	return true
+}
+
+// stringPtrEqual treats two nil pointers as equal and otherwise compares the strings
+func stringPtrEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ExportJSON writes cards to w as a single pretty-printed JSON array
+func ExportJSON(w io.Writer, cards []Card) error {
+	if cards == nil {
+		cards = []Card{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(cards)
+}
+
+// ExportNDJSON writes cards to w as newline-delimited JSON, one card object per line.
+// This form streams well into tools like jq or line-oriented data pipelines.
+func ExportNDJSON(w io.Writer, cards []Card) error {
+	encoder := json.NewEncoder(w)
+	for _, card := range cards {
+		if err := encoder.Encode(card); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportBulk writes every printing in the database to w as a JSON array of card
+// objects, the same shape as Scryfall's default_cards bulk file. Cards are written
+// one oracle card's printings at a time, so the whole database is never held in memory.
+// With ClientOptions.HideContentWarnings, flagged printings are left out.
+func (c *Client) ExportBulk(w io.Writer) error {
+	ctx := context.Background()
+	queries, err := c.queries()
+	if err != nil {
+		return err
+	}
+
+	oracles, err := queries.GetOracleCards(ctx)
+	if err != nil {
+		return fmt.Errorf("error loading cards: %v", err)
+	}
+
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	first := true
+	for _, oracle := range oracles {
+		rows, err := queries.GetPrintingsByOracleID(ctx, oracle.OracleID)
+		if err != nil {
+			return fmt.Errorf("error loading printings for %s: %v", oracle.Name, err)
+		}
+		printings, err := c.cardsFromPrintings(ctx, rows)
+		if err != nil {
+			return err
+		}
+
+		for _, printing := range printings {
+			if c.hideContentWarnings && printing.HasContentWarning() {
+				continue
+			}
+			data, err := json.Marshal(printing)
+			if err != nil {
+				return fmt.Errorf("error encoding %s: %v", printing.Name, err)
+			}
+			separator := ",\n"
+			if first {
+				separator = "\n"
+				first = false
+			}
+			if _, err := io.WriteString(w, separator); err != nil {
+				return err
+			}
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+		}
+	}
+	_, err = io.WriteString(w, "\n]\n")
+	return err
+}
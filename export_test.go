@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	delverFixture    = `cards_search@q=%21%22Delver+of+Secrets%22.json`
+	fireIceFixture   = `cards_search@q=%21%22Fire+%2F%2F+Ice%22.json`
+	saprolingFixture = `cards_search@q=%21%22Saproling%22+include%3Aextras.json`
+)
+
+// exportTestCards returns cards with URL fields, card_faces, image_uris and prices set
+func exportTestCards(t *testing.T) []Card {
+	t.Helper()
+	var cards []Card
+	cards = append(cards, fixtureCards(t, delverFixture)...)
+	cards = append(cards, fixtureCards(t, fireIceFixture)...)
+
+	var card Card
+	if err := json.Unmarshal(cardJSON(7, true), &card); err != nil {
+		t.Fatal(err)
+	}
+	cards = append(cards, card)
+
+	if len(cards[0].CardFaces) != 2 || cards[2].Prices["usd"] == nil || cards[2].URI.String() == "" {
+		t.Fatal("test cards are missing the faces, prices or URIs the round trip should cover")
+	}
+	return cards
+}
+
+func TestExportJSONRoundTrip(t *testing.T) {
+	cards := exportTestCards(t)
+
+	var buf bytes.Buffer
+	if err := ExportJSON(&buf, cards); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []Card
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("decoding ExportJSON output: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, cards) {
+		t.Errorf("ExportJSON round trip changed the cards:\ngot  %+v\nwant %+v", decoded, cards)
+	}
+}
+
+func TestExportNDJSONRoundTrip(t *testing.T) {
+	cards := exportTestCards(t)
+
+	var buf bytes.Buffer
+	if err := ExportNDJSON(&buf, cards); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded []Card
+	scanner := bufio.NewScanner(&buf)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var card Card
+		if err := json.Unmarshal(scanner.Bytes(), &card); err != nil {
+			t.Fatalf("line %d: %v", len(decoded)+1, err)
+		}
+		decoded = append(decoded, card)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, cards) {
+		t.Errorf("ExportNDJSON round trip changed the cards:\ngot  %+v\nwant %+v", decoded, cards)
+	}
+}
+
+func TestExportJSONEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportJSON(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "[]" {
+		t.Errorf("ExportJSON(nil) = %q, want []", got)
+	}
+}
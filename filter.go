@@ -0,0 +1,148 @@
+package main
+
+import "strings"
+
+// CardPredicate reports whether a card should be kept by FilterCards
+type CardPredicate func(Card) bool
+
+// CardFilter decides which cards the crawl stores, see FetchScryfallQuery.
+// It is the same shape as CardPredicate, so any predicate can be used as a filter.
+type CardFilter = CardPredicate
+
+// FilterCards returns the cards that satisfy every predicate (AND composition).
+// With no predicates every card is returned.
+func FilterCards(cards []Card, predicates ...CardPredicate) []Card {
+	var filtered []Card
+	for _, card := range cards {
+		if matchesAll(card, predicates) {
+			filtered = append(filtered, card)
+		}
+	}
+	return filtered
+}
+
+func matchesAll(card Card, predicates []CardPredicate) bool {
+	for _, predicate := range predicates {
+		if !predicate(card) {
+			return false
+		}
+	}
+	return true
+}
+
+// Not inverts a predicate
+func Not(predicate CardPredicate) CardPredicate {
+	return func(card Card) bool {
+		return !predicate(card)
+	}
+}
+
+// InGame keeps printings available in game
+func InGame(game Game) CardPredicate {
+	return func(card Card) bool {
+		return card.HasGame(game)
+	}
+}
+
+// NotDigital keeps printings that weren't released only in a video game, see
+// Card.Digital, so the crawl skips Arena and MTGO exclusives. It usually agrees with
+// InGame(GamePaper), but Games lists where a printing can be played rather than how
+// it was released, so the two can differ for printings with incomplete Games.
+func NotDigital() CardPredicate {
+	return func(card Card) bool {
+		return !card.Digital
+	}
+}
+
+// RarityAtLeast keeps printings whose rarity is at or above rarity,
+// ordered common < uncommon < rare < special < mythic < bonus
+func RarityAtLeast(rarity Rarity) CardPredicate {
+	return func(card Card) bool {
+		return card.RarityKind().AtLeast(rarity)
+	}
+}
+
+// InFormat keeps cards that are legal (or restricted) in format
+func InFormat(format Format) CardPredicate {
+	return func(card Card) bool {
+		legality := card.Legalities[string(format)]
+		return legality == "legal" || legality == "restricted"
+	}
+}
+
+// HasKeyword keeps cards that have keyword, compared case-insensitively
+func HasKeyword(keyword string) CardPredicate {
+	return func(card Card) bool {
+		for _, k := range card.Keywords {
+			if strings.EqualFold(k, keyword) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// OnReservedList keeps cards on the Reserved List
+func OnReservedList() CardFilter {
+	return func(card Card) bool {
+		return card.Reserved
+	}
+}
+
+// IsFirstPrinting keeps printings that aren't reprints. In the crawl every printing
+// must pass, so this keeps only cards that have never been reprinted.
+func IsFirstPrinting() CardFilter {
+	return func(card Card) bool {
+		return !card.Reprint
+	}
+}
+
+// NoContentWarning keeps cards without a content warning, see Card.HasContentWarning
+func NoContentWarning() CardFilter {
+	return func(card Card) bool {
+		return !card.HasContentWarning()
+	}
+}
+
+// NotCommonUncommonOnArena rejects printings that are common or uncommon on Arena.
+// In the crawl this skips any card that has ever been a common or uncommon on Arena.
+func NotCommonUncommonOnArena() CardFilter {
+	return func(card Card) bool {
+		return !(card.IsOnArena() && (card.RarityKind() == Common || card.RarityKind() == Uncommon))
+	}
+}
+
+// DedupMode chooses what MergeCardSets treats as the same card
+type DedupMode int
+
+const (
+	DedupByPrinting DedupMode = iota // same Scryfall ID, one entry per printing
+	DedupByOracle                    // same OracleID, one entry per card regardless of printing
+)
+
+// MergeCardSets combines the results of several searches into one slice without
+// duplicates, keeping the first occurrence of each card in the order seen.
+// Cards without an OracleID are compared by ID in DedupByOracle mode, and cards with
+// neither can't be told apart, so every one of them is kept.
+func MergeCardSets(mode DedupMode, sets ...[]Card) []Card {
+	seen := make(map[string]bool)
+	var merged []Card
+	for _, set := range sets {
+		for _, card := range set {
+			key := card.ID
+			if mode == DedupByOracle && card.OracleID != nil && *card.OracleID != "" {
+				key = *card.OracleID
+			}
+			if key == "" {
+				merged = append(merged, card)
+				continue
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, card)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// cardNames returns the names of cards, for comparing filter results
+func cardNames(cards []Card) []string {
+	names := make([]string, len(cards))
+	for i, card := range cards {
+		names[i] = card.Name
+	}
+	return names
+}
+
+func TestFilterCards(t *testing.T) {
+	cards := []Card{
+		{Name: "Bolt", Rarity: "common", Games: []string{"paper", "arena"}},
+		{Name: "Jace", Rarity: "mythic", Games: []string{"paper", "mtgo"}},
+		{Name: "Mox", Rarity: "rare", Games: []string{"paper"}, Reserved: true},
+		{Name: "Serra", Rarity: "uncommon", Games: []string{"mtgo"}, Keywords: []string{"Flying"}},
+	}
+	always := func(Card) bool { return true }
+	never := func(Card) bool { return false }
+
+	tests := []struct {
+		name       string
+		predicates []CardPredicate
+		want       []string
+	}{
+		{"no predicates keeps every card", nil, []string{"Bolt", "Jace", "Mox", "Serra"}},
+		{"single predicate", []CardPredicate{InGame(GamePaper)}, []string{"Bolt", "Jace", "Mox"}},
+		{"every predicate must pass", []CardPredicate{InGame(GamePaper), RarityAtLeast(Rare)}, []string{"Jace", "Mox"}},
+		{"three predicates", []CardPredicate{InGame(GamePaper), RarityAtLeast(Rare), OnReservedList()}, []string{"Mox"}},
+		{"one rejecting predicate rejects everything", []CardPredicate{always, never, InGame(GamePaper)}, nil},
+		{"always passes", []CardPredicate{always}, []string{"Bolt", "Jace", "Mox", "Serra"}},
+		{"Not inverts", []CardPredicate{Not(InGame(GamePaper))}, []string{"Serra"}},
+		{"Not composes with other predicates", []CardPredicate{InGame(GamePaper), Not(RarityAtLeast(Rare))}, []string{"Bolt"}},
+		{"Not of Not is the predicate", []CardPredicate{Not(Not(HasKeyword("flying")))}, []string{"Serra"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FilterCards(cards, tt.predicates...)
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if names := cardNames(got); !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("FilterCards = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeCardSets(t *testing.T) {
+	oracle := func(id string) *string { return &id }
+	boltA := Card{ID: "bolt-a", OracleID: oracle("bolt"), Name: "Bolt A"}
+	boltB := Card{ID: "bolt-b", OracleID: oracle("bolt"), Name: "Bolt B"}
+	jace := Card{ID: "jace", OracleID: oracle("jace"), Name: "Jace"}
+	noOracle := Card{ID: "token", Name: "Token"}
+	noIDs := []Card{{Name: "Handmade 1"}, {Name: "Handmade 2"}}
+
+	tests := []struct {
+		name string
+		mode DedupMode
+		sets [][]Card
+		want []string
+	}{
+		{"by printing keeps every printing", DedupByPrinting,
+			[][]Card{{boltA, jace}, {boltB, boltA}}, []string{"Bolt A", "Jace", "Bolt B"}},
+		{"by oracle keeps the first printing", DedupByOracle,
+			[][]Card{{boltA, jace}, {boltB, boltA}}, []string{"Bolt A", "Jace"}},
+		{"first-seen order across sets", DedupByOracle,
+			[][]Card{{jace}, {boltB, jace}, {boltA}}, []string{"Jace", "Bolt B"}},
+		{"by oracle falls back to ID", DedupByOracle,
+			[][]Card{{noOracle, jace}, {noOracle}}, []string{"Token", "Jace"}},
+		{"cards with no IDs are all kept by printing", DedupByPrinting,
+			[][]Card{noIDs, noIDs[:1]}, []string{"Handmade 1", "Handmade 2", "Handmade 1"}},
+		{"cards with no IDs are all kept by oracle", DedupByOracle,
+			[][]Card{noIDs, {jace}}, []string{"Handmade 1", "Handmade 2", "Jace"}},
+		{"no sets", DedupByPrinting, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MergeCardSets(tt.mode, tt.sets...)
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if names := cardNames(got); !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("MergeCardSets = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
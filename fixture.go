@@ -0,0 +1,23 @@
+package main
+
+import "github.com/ninesl/scryfall-api/scryfalltest"
+
+// NewFixtureClient returns a Client whose API requests are answered from the JSON
+// fixtures in dir (see the scryfalltest package) instead of Scryfall, with the
+// database disabled. Call close when done to stop the fixture server.
+func NewFixtureClient(dir string) (client *Client, close func(), err error) {
+	server := scryfalltest.NewServer(dir)
+
+	options := DefaultClientOptions
+	options.APIURL = server.URL
+	options.UserAgent = "ScryfallTest/1.0"
+	options.DisableDB = true
+	options.TrustURIHost = true // fixtures hold Scryfall's own URIs
+
+	client, err = NewClientWithOptions(options)
+	if err != nil {
+		server.Close()
+		return nil, nil, err
+	}
+	return client, server.Close, nil
+}
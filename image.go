@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrNoImage is returned by the image downloads when Scryfall marks a card's image as
+// missing or a placeholder, rather than downloading the placeholder
+var ErrNoImage = errors.New("card has no real image yet")
+
+// ImageSize is one of the image_uris keys Scryfall provides for a card
+type ImageSize string
+
+const (
+	ImageSmall      ImageSize = "small"       // 146 x 204 JPG
+	ImageNormal     ImageSize = "normal"      // 488 x 680 JPG
+	ImageLarge      ImageSize = "large"       // 672 x 936 JPG
+	ImagePNG        ImageSize = "png"         // 745 x 1040 transparent PNG
+	ImageArtCrop    ImageSize = "art_crop"    // just the artwork, varying size
+	ImageBorderCrop ImageSize = "border_crop" // 480 x 680 JPG cropped to the border
+)
+
+// mediaType is the Content-Type Scryfall serves images of this size as
+func (size ImageSize) mediaType() string {
+	if size == ImagePNG {
+		return "image/png"
+	}
+	return "image/jpeg"
+}
+
+// ImageURL returns the card's image at size. Double-faced cards have no card-level
+// image, so their front face is used instead; see FaceImageURL.
+func (c *Card) ImageURL(size ImageSize) (string, error) {
+	if uri := c.ImageURIs[string(size)]; uri != "" {
+		return uri, nil
+	}
+	if len(c.CardFaces) > 0 {
+		return c.FaceImageURL(0, size)
+	}
+	return "", fmt.Errorf("card %s has no %s image", c.Name, size)
+}
+
+// FaceImageURL returns the image of face faceIndex at size. Double-faced layouts
+// (transform, modal_dfc, ...) have an image per face; split, flip and adventure
+// cards print every face on one image, so the card's own image is returned.
+func (c *Card) FaceImageURL(faceIndex int, size ImageSize) (string, error) {
+	if faceIndex < 0 || (faceIndex > 0 && faceIndex >= len(c.CardFaces)) {
+		return "", fmt.Errorf("card %s has no face %d", c.Name, faceIndex)
+	}
+
+	var faceURI string
+	if faceIndex < len(c.CardFaces) {
+		faceURI = c.CardFaces[faceIndex].ImageURIs[string(size)]
+	}
+	cardURI := c.ImageURIs[string(size)]
+
+	// prefer the image the layout calls for, but fall back to whichever one exists
+	if c.IsDoubleFaced() && faceURI != "" {
+		return faceURI, nil
+	}
+	if cardURI != "" {
+		return cardURI, nil
+	}
+	if faceURI != "" {
+		return faceURI, nil
+	}
+	return "", fmt.Errorf("card %s has no %s image for face %d", c.Name, size, faceIndex)
+}
+
+// DownloadImage writes the card's image at size to w, see ImageURL. It fails if the
+// server answers with anything other than a PNG for ImagePNG or a JPEG for other sizes,
+// and returns ErrNoImage for cards whose image is missing or a placeholder.
+func (c *Client) DownloadImage(card *Card, size ImageSize, w io.Writer) error {
+	if err := checkImageStatus(card); err != nil {
+		return err
+	}
+	uri, err := card.ImageURL(size)
+	if err != nil {
+		return err
+	}
+	if err := c.download(uri, size.mediaType(), w); err != nil {
+		return fmt.Errorf("error downloading %s image of %s: %v", size, card.Name, err)
+	}
+	return nil
+}
+
+// DownloadAllFaceImages returns the image at size of every face of a double-faced
+// card, front first, skipping faces with no image. Other cards have one image,
+// which is returned as a single element. Like DownloadImage it returns ErrNoImage
+// for cards without a real image.
+func (c *Client) DownloadAllFaceImages(card *Card, size ImageSize) ([][]byte, error) {
+	if err := checkImageStatus(card); err != nil {
+		return nil, err
+	}
+	var uris []string
+	if card.IsDoubleFaced() {
+		for i := range card.CardFaces {
+			if uri, err := card.FaceImageURL(i, size); err == nil {
+				uris = append(uris, uri)
+			}
+		}
+	} else if uri, err := card.ImageURL(size); err == nil {
+		uris = append(uris, uri)
+	}
+	if len(uris) == 0 {
+		return nil, fmt.Errorf("card %s has no %s image", card.Name, size)
+	}
+
+	images := make([][]byte, 0, len(uris))
+	for _, uri := range uris {
+		var buf bytes.Buffer
+		if err := c.download(uri, size.mediaType(), &buf); err != nil {
+			return nil, fmt.Errorf("error downloading %s image of %s: %v", size, card.Name, err)
+		}
+		images = append(images, buf.Bytes())
+	}
+	return images, nil
+}
+
+// checkImageStatus returns ErrNoImage when card's image is missing or a placeholder.
+// An empty ImageStatus, as on cards built by hand, isn't checked.
+func checkImageStatus(card *Card) error {
+	switch card.ImageStatusKind() {
+	case ImageStatusMissing, ImageStatusPlaceholder:
+		return ErrNoImage
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// searchFixture runs query against the fixtures in scryfalltest/testdata and returns
+// the single card it finds
+func searchFixture(t *testing.T, query string) Card {
+	t.Helper()
+	client, closeServer, err := NewFixtureClient("scryfalltest/testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeServer()
+
+	cards, err := client.SearchCardsByQuery(query)
+	if err != nil {
+		t.Fatalf("searching %s: %v", query, err)
+	}
+	if len(cards) != 1 {
+		t.Fatalf("searching %s found %d cards, want 1", query, len(cards))
+	}
+	return cards[0]
+}
+
+func TestImageURLDoubleFaced(t *testing.T) {
+	delver := searchFixture(t, `!"Delver of Secrets"`)
+	front := delver.CardFaces[0].ImageURIs["normal"]
+	back := delver.CardFaces[1].ImageURIs["normal"]
+	if front == "" || back == "" || front == back {
+		t.Fatalf("fixture faces should have distinct images, got %q and %q", front, back)
+	}
+
+	modal := delver
+	modal.Layout = string(LayoutModalDFC)
+
+	for _, card := range []Card{delver, modal} {
+		t.Run(card.Layout, func(t *testing.T) {
+			if got, err := card.ImageURL(ImageNormal); err != nil || got != front {
+				t.Errorf("ImageURL = %q, %v; want the front face %q", got, err, front)
+			}
+			if got, err := card.FaceImageURL(0, ImageNormal); err != nil || got != front {
+				t.Errorf("FaceImageURL(0) = %q, %v; want %q", got, err, front)
+			}
+			if got, err := card.FaceImageURL(1, ImageNormal); err != nil || got != back {
+				t.Errorf("FaceImageURL(1) = %q, %v; want %q", got, err, back)
+			}
+			if got, err := card.FaceImageURL(1, ImagePNG); err != nil || !strings.HasSuffix(got, ".png") {
+				t.Errorf("FaceImageURL(1, png) = %q, %v; want the back face png", got, err)
+			}
+			if _, err := card.FaceImageURL(2, ImageNormal); err == nil {
+				t.Error("FaceImageURL(2) succeeded on a two-faced card")
+			}
+		})
+	}
+}
+
+func TestImageURLSingleImageLayouts(t *testing.T) {
+	fireIce := searchFixture(t, `!"Fire // Ice"`)
+	image := fireIce.ImageURIs["normal"]
+	if image == "" || len(fireIce.CardFaces) != 2 {
+		t.Fatal("Fire // Ice fixture should have a card image and two faces")
+	}
+
+	// adventure and flip cards print both faces on one image too; give the faces
+	// images of their own to check the card's image still wins
+	adventure := fireIce
+	adventure.Layout = string(LayoutAdventure)
+	adventure.CardFaces = append([]CardFace(nil), fireIce.CardFaces...)
+	for i := range adventure.CardFaces {
+		adventure.CardFaces[i].ImageURIs = map[string]string{"normal": "https://cards.scryfall.io/normal/face.jpg"}
+	}
+
+	for _, card := range []Card{fireIce, adventure} {
+		t.Run(card.Layout, func(t *testing.T) {
+			if got, err := card.ImageURL(ImageNormal); err != nil || got != image {
+				t.Errorf("ImageURL = %q, %v; want the card image %q", got, err, image)
+			}
+			for face := range card.CardFaces {
+				if got, err := card.FaceImageURL(face, ImageNormal); err != nil || got != image {
+					t.Errorf("FaceImageURL(%d) = %q, %v; want the card image %q", face, got, err, image)
+				}
+			}
+		})
+	}
+}
+
+func TestImageURLMissing(t *testing.T) {
+	card := Card{Name: "No Image"}
+	if _, err := card.ImageURL(ImageNormal); err == nil {
+		t.Error("ImageURL succeeded on a card with no images")
+	}
+	if _, err := card.FaceImageURL(-1, ImageNormal); err == nil {
+		t.Error("FaceImageURL(-1) succeeded")
+	}
+}
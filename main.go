@@ -1,13 +1,21 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"log/slog"
 )
 
 func main() {
 	// Initialize client
-	client, err := NewClient("MagicClubDB")
+	options := DefaultClientOptions
+	options.UserAgent = "MagicClubDB/1.0"
+	options.OnProgress = func(done, total int, msg string) {
+		fmt.Println(msg)
+	}
+	options.Logger = slog.Default()
+	client, err := NewClientWithOptions(options)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -25,7 +33,7 @@ func main() {
 	switch choice {
 	case "1":
 		fmt.Println("Fetching filtered cards from Scryfall API...")
-		if err := client.FetchFilteredScryfallAPI(); err != nil {
+		if err := client.FetchFilteredScryfallAPI(NotCommonUncommonOnArena()); err != nil {
 			log.Fatal(err)
 		}
 		fmt.Println("Done!")
@@ -64,6 +72,10 @@ func main() {
 
 		fmt.Printf("Searching for: %s\n", query)
 		cards, err := client.SearchCardsByQuery(query)
+		if errors.Is(err, ErrNoCardsFound) {
+			fmt.Println("No cards found.")
+			return
+		}
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -0,0 +1,25 @@
+-- Full-text index over card names and oracle text for offline searching.
+-- cards_fts is an external-content table: it stores only the index and reads
+-- the text from cards, kept in sync by the triggers below.
+CREATE VIRTUAL TABLE IF NOT EXISTS cards_fts USING fts5(
+    name,
+    oracle_text,
+    content='cards',
+    content_rowid='rowid'
+);
+
+CREATE TRIGGER IF NOT EXISTS cards_fts_insert AFTER INSERT ON cards BEGIN
+    INSERT INTO cards_fts(rowid, name, oracle_text) VALUES (new.rowid, new.name, new.oracle_text);
+END;
+
+CREATE TRIGGER IF NOT EXISTS cards_fts_delete AFTER DELETE ON cards BEGIN
+    INSERT INTO cards_fts(cards_fts, rowid, name, oracle_text) VALUES ('delete', old.rowid, old.name, old.oracle_text);
+END;
+
+CREATE TRIGGER IF NOT EXISTS cards_fts_update AFTER UPDATE ON cards BEGIN
+    INSERT INTO cards_fts(cards_fts, rowid, name, oracle_text) VALUES ('delete', old.rowid, old.name, old.oracle_text);
+    INSERT INTO cards_fts(rowid, name, oracle_text) VALUES (new.rowid, new.name, new.oracle_text);
+END;
+
+-- index cards stored before this migration
+INSERT INTO cards_fts(cards_fts) VALUES ('rebuild');
@@ -0,0 +1,8 @@
+-- Rulings are published per oracle card, so every printing shares one set of rows.
+CREATE TABLE IF NOT EXISTS rulings (
+    oracle_id TEXT NOT NULL, -- Foreign key to cards table
+    source TEXT NOT NULL, -- "wotc" or "scryfall"
+    published_at TEXT NOT NULL, -- YYYY-MM-DD
+    comment TEXT NOT NULL,
+    PRIMARY KEY (oracle_id, source, published_at, comment)
+);
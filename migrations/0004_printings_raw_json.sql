@@ -0,0 +1,3 @@
+-- The card object exactly as Scryfall returned it, kept when ClientOptions.StoreRawJSON
+-- is set so fields the typed columns don't cover are never lost.
+ALTER TABLE printings ADD COLUMN raw_json TEXT;
@@ -0,0 +1,66 @@
+-- Rebuilds the index from 0002. That one was keyed on cards' implicit rowid, which
+-- VACUUM may renumber because cards has a TEXT primary key, and it only saw
+-- cards.oracle_text, which multi-face cards leave empty.
+DROP TRIGGER IF EXISTS cards_fts_insert;
+DROP TRIGGER IF EXISTS cards_fts_delete;
+DROP TRIGGER IF EXISTS cards_fts_update;
+DROP TABLE IF EXISTS cards_fts;
+
+-- A stable integer key per card for the index rowid. The triggers check for an
+-- existing key instead of using INSERT OR IGNORE, which the upsert's own conflict
+-- handling would override.
+CREATE TABLE IF NOT EXISTS cards_fts_keys (
+    id INTEGER PRIMARY KEY,
+    oracle_id TEXT NOT NULL UNIQUE
+);
+
+-- cards_fts keeps its own copy of the text. oracle_text holds the card's text
+-- followed by the text of each of its faces.
+CREATE VIRTUAL TABLE IF NOT EXISTS cards_fts USING fts5(
+    oracle_id UNINDEXED,
+    name,
+    oracle_text
+);
+
+CREATE TRIGGER IF NOT EXISTS cards_fts_insert AFTER INSERT ON cards BEGIN
+    INSERT INTO cards_fts_keys(oracle_id) SELECT new.oracle_id
+    WHERE NOT EXISTS (SELECT 1 FROM cards_fts_keys WHERE oracle_id = new.oracle_id);
+    INSERT INTO cards_fts(rowid, oracle_id, name, oracle_text) VALUES (
+        (SELECT id FROM cards_fts_keys WHERE oracle_id = new.oracle_id),
+        new.oracle_id,
+        new.name,
+        concat_ws(char(10), new.oracle_text,
+            (SELECT group_concat(json_extract(value, '$.oracle_text'), char(10)) FROM json_each(new.card_faces)))
+    );
+END;
+
+CREATE TRIGGER IF NOT EXISTS cards_fts_delete AFTER DELETE ON cards BEGIN
+    DELETE FROM cards_fts WHERE rowid = (SELECT id FROM cards_fts_keys WHERE oracle_id = old.oracle_id);
+    DELETE FROM cards_fts_keys WHERE oracle_id = old.oracle_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS cards_fts_update AFTER UPDATE ON cards BEGIN
+    DELETE FROM cards_fts WHERE rowid = (SELECT id FROM cards_fts_keys WHERE oracle_id = old.oracle_id);
+    DELETE FROM cards_fts_keys WHERE oracle_id = old.oracle_id AND old.oracle_id <> new.oracle_id;
+    INSERT INTO cards_fts_keys(oracle_id) SELECT new.oracle_id
+    WHERE NOT EXISTS (SELECT 1 FROM cards_fts_keys WHERE oracle_id = new.oracle_id);
+    INSERT INTO cards_fts(rowid, oracle_id, name, oracle_text) VALUES (
+        (SELECT id FROM cards_fts_keys WHERE oracle_id = new.oracle_id),
+        new.oracle_id,
+        new.name,
+        concat_ws(char(10), new.oracle_text,
+            (SELECT group_concat(json_extract(value, '$.oracle_text'), char(10)) FROM json_each(new.card_faces)))
+    );
+END;
+
+-- index cards stored before this migration
+INSERT OR IGNORE INTO cards_fts_keys(oracle_id) SELECT oracle_id FROM cards;
+INSERT INTO cards_fts(rowid, oracle_id, name, oracle_text)
+SELECT
+    k.id,
+    c.oracle_id,
+    c.name,
+    concat_ws(char(10), c.oracle_text,
+        (SELECT group_concat(json_extract(value, '$.oracle_text'), char(10)) FROM json_each(c.card_faces)))
+FROM cards c
+JOIN cards_fts_keys k ON k.oracle_id = c.oracle_id;
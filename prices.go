@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ninesl/scryfall-api/scryfall"
+)
+
+// Prices holds a card's daily prices parsed from Card.Prices.
+// A nil field means Scryfall has no price for that market/finish.
+type Prices struct {
+	USD       *float64
+	USDFoil   *float64
+	USDEtched *float64
+	EUR       *float64
+	EURFoil   *float64
+	Tix       *float64
+}
+
+// ParsePrices converts the string prices Scryfall returns into floats
+func (c *Card) ParsePrices() Prices {
+	return Prices{
+		USD:       c.price("usd"),
+		USDFoil:   c.price("usd_foil"),
+		USDEtched: c.price("usd_etched"),
+		EUR:       c.price("eur"),
+		EURFoil:   c.price("eur_foil"),
+		Tix:       c.price("tix"),
+	}
+}
+
+// price parses a single entry of Card.Prices, returning nil when missing or malformed
+func (c *Card) price(key string) *float64 {
+	raw := c.Prices[key]
+	if raw == nil {
+		return nil
+	}
+	value, err := strconv.ParseFloat(*raw, 64)
+	if err != nil {
+		return nil
+	}
+	return &value
+}
+
+// Currency selects which of a card's prices to use
+type Currency string
+
+const (
+	CurrencyUSD Currency = "usd"
+	CurrencyEUR Currency = "eur"
+	CurrencyTix Currency = "tix" // MTGO event tickets, which have no separate foil price
+)
+
+// priceKey returns the Card.Prices key for currency, using the foil price when foil is set
+func (cur Currency) priceKey(foil bool) (string, error) {
+	switch cur {
+	case CurrencyUSD, CurrencyEUR:
+		if foil {
+			return string(cur) + "_foil", nil
+		}
+		return string(cur), nil
+	case CurrencyTix:
+		return string(cur), nil
+	default:
+		return "", fmt.Errorf("unknown currency %q", cur)
+	}
+}
+
+// DeckPrice totals the price of a resolved deck, multiplying each card by its quantity.
+// Cards without a price in currency are left out of the total and listed in the
+// returned warnings; err is only set for an unknown currency.
+func DeckPrice(entries []ResolvedCard, currency Currency, foil bool) (float64, []string, error) {
+	key, err := currency.priceKey(foil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var total float64
+	var missing []string
+	for _, entry := range entries {
+		price := entry.Card.price(key)
+		if price == nil {
+			missing = append(missing, fmt.Sprintf("%s (%s): no %s price", entry.Card.Name, entry.Card.Set, key))
+			continue
+		}
+		total += *price * float64(entry.Quantity)
+	}
+	return total, missing, nil
+}
+
+// RefreshPrices updates the prices of every stored printing without touching any other
+// column, looking printings up through /cards/collection 75 at a time. It returns how
+// many printings were updated; printings Scryfall no longer knows are left as they are.
+// Prices fetched before a failed request are still stored.
+func (c *Client) RefreshPrices(ctx context.Context) (int, error) {
+	queries, err := c.queries()
+	if err != nil {
+		return 0, err
+	}
+
+	ids, err := queries.ListPrintingIDs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error loading printing ids: %v", err)
+	}
+
+	identifiers := make([]CardIdentifier, len(ids))
+	for i, id := range ids {
+		identifiers[i] = CardIdentifier{ID: id}
+	}
+
+	// a failed lookup still returns the prices fetched before it, so store those first
+	list, _, fetchErr := c.getCollection(ctx, identifiers)
+	updated, err := c.updatePrices(ctx, list.Data)
+	if err != nil {
+		return updated, err
+	}
+	if fetchErr != nil {
+		return updated, fmt.Errorf("error fetching prices: %v", fetchErr)
+	}
+	return updated, nil
+}
+
+// updatePrices writes the prices of cards to their printings in a single transaction
+func (c *Client) updatePrices(ctx context.Context, cards []Card) (int, error) {
+	err := c.WithTx(ctx, func(queries *scryfall.Queries) error {
+		for _, card := range cards {
+			err := queries.UpdatePrintingPrices(ctx, scryfall.UpdatePrintingPricesParams{
+				Prices: toJSONStringDirect(card.Prices),
+				ID:     card.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("error updating prices for %s: %v", card.Name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(cards), nil
+}
+
+// USDPrice returns the nonfoil USD price and whether Scryfall has one
+func (c *Card) USDPrice() (float64, bool) {
+	price := c.price("usd")
+	if price == nil {
+		return 0, false
+	}
+	return *price, true
+}
+
+// SetExchangeRates sets the rates PriceIn converts with, as units of each currency per
+// one US dollar, e.g. {"gbp": 0.79, CurrencyEUR: 0.92}. Any Currency value can be used
+// for currencies Scryfall doesn't price in. It replaces any rates set before and is
+// safe to call while other goroutines are converting prices.
+func (c *Client) SetExchangeRates(rates map[Currency]float64) {
+	copied := make(map[Currency]float64, len(rates))
+	for currency, rate := range rates {
+		copied[currency] = rate
+	}
+
+	c.ratesMu.Lock()
+	defer c.ratesMu.Unlock()
+	c.exchangeRates = copied
+}
+
+// PriceIn returns the card's nonfoil price in currency and whether one could be found.
+// Prices Scryfall has in currency are returned as is; otherwise the USD price, or
+// failing that the EUR price, is converted with the rates from SetExchangeRates.
+// Tix prices are never converted.
+func (c *Client) PriceIn(card *Card, currency Currency) (float64, bool) {
+	switch currency {
+	case CurrencyUSD, CurrencyEUR, CurrencyTix:
+		if price := card.price(string(currency)); price != nil {
+			return *price, true
+		}
+		if currency == CurrencyTix {
+			return 0, false
+		}
+	}
+
+	c.ratesMu.RLock()
+	defer c.ratesMu.RUnlock()
+
+	rate, ok := c.exchangeRates[currency]
+	if currency == CurrencyUSD {
+		rate, ok = 1, true
+	}
+	if !ok {
+		return 0, false
+	}
+
+	if usd := card.price("usd"); usd != nil {
+		return *usd * rate, true
+	}
+	if eur := card.price("eur"); eur != nil {
+		if eurRate := c.exchangeRates[CurrencyEUR]; eurRate > 0 {
+			return *eur / eurRate * rate, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AllPrintings returns every printing of card by walking all pages of its PrintsSearchURI.
+// The result is the raw Scryfall list, one entry per printing object.
+// Cards without a PrintsSearchURI, such as ones loaded from the database, are
+// looked up by oracle ID instead.
+func (c *Client) AllPrintings(card *Card) ([]Card, error) {
+	return c.AllPrintingsContext(context.Background(), card)
+}
+
+// AllPrintingsContext is AllPrintings stopping with ctx's error when ctx is done
+func (c *Client) AllPrintingsContext(ctx context.Context, card *Card) ([]Card, error) {
+	if card.PrintsSearchURI.String() == "" {
+		if oracleID, ok := card.oracleID(); ok {
+			return c.PrintingsByOracleIDContext(ctx, oracleID)
+		}
+	}
+
+	printings, err := c.getCardPrintings(ctx, card.PrintsSearchURI.String())
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching printings for %s: %v", card.Name, err)
+	}
+	return printings, nil
+}
+
+// PrintingsByOracleID returns every printing of the card with oracleID in release order,
+// the same search a card's prints_search_uri runs
+func (c *Client) PrintingsByOracleID(oracleID string) ([]Card, error) {
+	return c.PrintingsByOracleIDContext(context.Background(), oracleID)
+}
+
+// PrintingsByOracleIDContext is PrintingsByOracleID stopping with ctx's error when ctx is done
+func (c *Client) PrintingsByOracleIDContext(ctx context.Context, oracleID string) ([]Card, error) {
+	query := fmt.Sprintf("oracleid:%s unique:prints order:released", oracleID)
+	printings, _, err := c.SearchCardsWithOptionsContext(ctx, query, SearchOptions{})
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching printings for oracle id %s: %v", oracleID, err)
+	}
+	return printings, nil
+}
+
+// UniquePrintings is like AllPrintings but collapses printings that share a
+// set and collector number into one representative card, so each physical
+// printing is counted once. The first printing seen is kept and its Finishes
+// are extended with any finishes (foil, nonfoil, etched) from the duplicates.
+func (c *Client) UniquePrintings(card *Card) ([]Card, error) {
+	printings, err := c.AllPrintings(card)
+	if err != nil {
+		return nil, err
+	}
+	return uniquePrintings(printings), nil
+}
+
+// uniquePrintings collapses printings by (set, collector_number), preserving order
+func uniquePrintings(printings []Card) []Card {
+	type printingKey struct {
+		set             string
+		collectorNumber string
+	}
+
+	index := make(map[printingKey]int)
+	var unique []Card
+	for _, printing := range printings {
+		key := printingKey{printing.Set, printing.CollectorNumber}
+		if i, exists := index[key]; exists {
+			for _, finish := range printing.Finishes {
+				if !unique[i].HasFinish(Finish(finish)) {
+					unique[i].Finishes = append(unique[i].Finishes, finish)
+				}
+			}
+			continue
+		}
+
+		// copy Finishes so merging never writes into the caller's slice
+		printing.Finishes = append([]string(nil), printing.Finishes...)
+		index[key] = len(unique)
+		unique = append(unique, printing)
+	}
+	return unique
+}
+
+// PrintingsBySet returns every printing of card keyed by set code, each set's
+// printings ordered by collector number
+func (c *Client) PrintingsBySet(card *Card) (map[string][]Card, error) {
+	printings, err := c.AllPrintings(card)
+	if err != nil {
+		return nil, err
+	}
+
+	bySet := make(map[string][]Card)
+	for _, printing := range printings {
+		bySet[printing.Set] = append(bySet[printing.Set], printing)
+	}
+	for _, setPrintings := range bySet {
+		sort.SliceStable(setPrintings, func(i, j int) bool {
+			return collectorNumberLess(setPrintings[i].CollectorNumber, setPrintings[j].CollectorNumber)
+		})
+	}
+	return bySet, nil
+}
+
+// RarityByGame reports the rarities a card has been printed at in each game, given
+// its printings. Each game's rarities are deduplicated and sorted from common up,
+// e.g. {GameArena: [Uncommon, Rare], GamePaper: [Rare]}.
+func RarityByGame(printings []Card) map[Game][]Rarity {
+	seen := make(map[Game]map[Rarity]bool)
+	for _, printing := range printings {
+		rarity := Rarity(strings.ToLower(printing.Rarity))
+		for _, game := range printing.AvailableGames() {
+			if seen[game] == nil {
+				seen[game] = make(map[Rarity]bool)
+			}
+			seen[game][rarity] = true
+		}
+	}
+
+	byGame := make(map[Game][]Rarity, len(seen))
+	for game, rarities := range seen {
+		for rarity := range rarities {
+			byGame[game] = append(byGame[game], rarity)
+		}
+		sort.Slice(byGame[game], func(i, j int) bool {
+			a, b := byGame[game][i], byGame[game][j]
+			if a.Value() != b.Value() {
+				return a.Value() < b.Value()
+			}
+			return a < b
+		})
+	}
+	return byGame
+}
+
+// collectorNumberLess orders collector numbers by their numeric part, then as strings,
+// so "2" < "10" and "10" < "10a" < "10b"
+func collectorNumberLess(a, b string) bool {
+	numA, restA := splitCollectorNumber(a)
+	numB, restB := splitCollectorNumber(b)
+	if numA != numB {
+		return numA < numB
+	}
+	return restA < restB
+}
+
+// splitCollectorNumber splits a collector number like "123a" into 123 and "a".
+// Numbers without leading digits, like "A-12", sort after every numbered card.
+func splitCollectorNumber(number string) (int, string) {
+	digits := len(number) - len(strings.TrimLeft(number, "0123456789"))
+	if digits == 0 {
+		return math.MaxInt, number
+	}
+	value, err := strconv.Atoi(number[:digits])
+	if err != nil {
+		return math.MaxInt, number
+	}
+	return value, number[digits:]
+}
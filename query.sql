@@ -18,7 +18,7 @@ SELECT
     p.released_at
 FROM cards c
 JOIN printings p ON c.oracle_id = p.oracle_id
-ORDER BY c.name, p.released_at DESC;
+ORDER BY c.name, c.oracle_id, p.released_at DESC, p.id;
 
 -- Insert or update a card (oracle-level)
 -- name: UpsertCard :exec
@@ -71,11 +71,11 @@ INSERT INTO printings (
     printed_text, printed_type_line, promo, promo_types, purchase_uris, rarity,
     related_uris, released_at, reprint, scryfall_set_uri, set_name, set_search_uri,
     set_type, set_uri, "set", set_id, story_spotlight, textless, variation,
-    variation_of, security_stamp, watermark, preview
+    variation_of, security_stamp, watermark, preview, raw_json
 ) VALUES (
     ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
     ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
-    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 )
 ON CONFLICT(id) DO UPDATE SET
     oracle_id = excluded.oracle_id,
@@ -137,4 +137,128 @@ ON CONFLICT(id) DO UPDATE SET
     variation_of = excluded.variation_of,
     security_stamp = excluded.security_stamp,
     watermark = excluded.watermark,
-    preview = excluded.preview;
\ No newline at end of file
+    preview = excluded.preview,
+    raw_json = excluded.raw_json;
+
+-- Get a single card (oracle-level)
+-- name: GetCard :one
+SELECT * FROM cards
+WHERE oracle_id = ?;
+
+-- Get every card once, oracle-level fields only
+-- name: GetOracleCards :many
+SELECT * FROM cards
+ORDER BY name;
+
+-- Get all printings of a card, newest first
+-- name: GetPrintingsByOracleID :many
+SELECT * FROM printings
+WHERE oracle_id = ?
+ORDER BY released_at DESC;
+
+-- Get all printings illustrated by an artist, matched case-insensitively, newest first
+-- name: GetCardsByArtist :many
+SELECT * FROM printings
+WHERE LOWER(artist) = LOWER(sqlc.arg(artist))
+ORDER BY released_at DESC, "set", collector_number;
+
+-- Get all printings credited to an artist's Scryfall id, newest first
+-- name: GetCardsByArtistID :many
+SELECT * FROM printings
+WHERE EXISTS (SELECT 1 FROM json_each(printings.artist_ids) WHERE json_each.value = sqlc.arg(artist_id))
+ORDER BY released_at DESC, "set", collector_number;
+
+-- Get the id of every stored printing
+-- name: ListPrintingIDs :many
+SELECT id FROM printings
+ORDER BY id;
+
+-- Update only the prices of a printing
+-- name: UpdatePrintingPrices :exec
+UPDATE printings
+SET prices = ?
+WHERE id = ?;
+
+-- Full-text search over oracle text, best matches first.
+-- match is an FTS5 query, e.g. oracle_text:"draw a card"
+-- name: SearchOracleText :many
+SELECT cards.* FROM cards
+JOIN cards_fts ON cards_fts.oracle_id = cards.oracle_id
+WHERE cards_fts MATCH sqlc.arg(match)
+ORDER BY cards_fts.rank;
+
+-- Get all stored printings from a set
+-- name: GetPrintingsBySet :many
+SELECT * FROM printings
+WHERE "set" = sqlc.arg(set_code)
+ORDER BY collector_number;
+
+-- Get one page of cards that have printings, ordered by name, with all of their printings
+-- name: GetCardsWithPrintingsPaged :many
+SELECT 
+    c.oracle_id,
+    c.name,
+    c.layout,
+    c.cmc,
+    c.color_identity,
+    c.colors,
+    c.mana_cost,
+    c.oracle_text,
+    c.type_line,
+    p.id as printing_id,
+    p.rarity,
+    p.games,
+    p."set",
+    p.set_name,
+    p.released_at
+FROM cards c
+JOIN printings p ON c.oracle_id = p.oracle_id
+WHERE c.oracle_id IN (
+    SELECT DISTINCT pc.oracle_id FROM cards pc
+    JOIN printings pp ON pc.oracle_id = pp.oracle_id
+    ORDER BY pc.name, pc.oracle_id
+    LIMIT ? OFFSET ?
+)
+ORDER BY c.name, c.oracle_id, p.released_at DESC, p.id;
+
+-- Count the stored cards (oracle-level) that have at least one printing
+-- name: CountCards :one
+SELECT COUNT(DISTINCT c.oracle_id) FROM cards c
+JOIN printings p ON c.oracle_id = p.oracle_id;
+
+-- Get cards whose name matches a LIKE pattern, case-insensitively
+-- name: FindCardsByName :many
+SELECT * FROM cards
+WHERE name LIKE sqlc.arg(pattern) ESCAPE '\'
+ORDER BY name;
+
+-- Insert a ruling, skipping it if it's already stored
+-- name: UpsertRuling :exec
+INSERT INTO rulings (
+    oracle_id, source, published_at, comment
+) VALUES (
+    ?, ?, ?, ?
+)
+ON CONFLICT(oracle_id, source, published_at, comment) DO NOTHING;
+
+-- Delete every stored ruling of a card
+-- name: DeleteRulingsByOracleID :exec
+DELETE FROM rulings
+WHERE oracle_id = ?;
+
+-- Get all rulings of a card, oldest first
+-- name: GetRulingsByOracleID :many
+SELECT * FROM rulings
+WHERE oracle_id = ?
+ORDER BY published_at, source;
+
+-- Get the raw Scryfall JSON stored for a printing
+-- name: GetPrintingRawJSON :one
+SELECT raw_json FROM printings
+WHERE id = ?;
+
+-- Get cards with a keyword, matched case-insensitively
+-- name: GetCardsByKeyword :many
+SELECT * FROM cards
+WHERE EXISTS (SELECT 1 FROM json_each(cards.keywords) WHERE LOWER(json_each.value) = LOWER(sqlc.arg(keyword)))
+ORDER BY name;
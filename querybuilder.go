@@ -0,0 +1,157 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Comparison is an operator used by numeric and ordered search keywords
+type Comparison string
+
+const (
+	Equal       Comparison = "="
+	NotEqual    Comparison = "!="
+	LessThan    Comparison = "<"
+	AtMost      Comparison = "<="
+	GreaterThan Comparison = ">"
+	AtLeast     Comparison = ">="
+)
+
+// QueryBuilder builds Scryfall search query strings without hand-writing them.
+//
+//	query := NewQueryBuilder().Color("red").CMC(3).Rarity(AtLeast, Rare).Not().Game("arena").Build()
+//	// c:red cmc=3 r>=rare -game:arena
+//
+// Every method appends one term; terms are joined with spaces, which Scryfall treats as AND.
+type QueryBuilder struct {
+	terms  []string
+	negate bool
+}
+
+func NewQueryBuilder() *QueryBuilder {
+	return &QueryBuilder{}
+}
+
+// Not negates the next term (or group) added to the builder
+func (q *QueryBuilder) Not() *QueryBuilder {
+	q.negate = true
+	return q
+}
+
+// Color matches cards whose colors include color, e.g. "red", "r", "uw" or "azorius"
+func (q *QueryBuilder) Color(color string) *QueryBuilder {
+	return q.keyword("c", ":", color)
+}
+
+// ColorIdentity matches cards whose color identity fits within identity
+func (q *QueryBuilder) ColorIdentity(identity string) *QueryBuilder {
+	return q.keyword("id", ":", identity)
+}
+
+// CMC matches cards with exactly the given mana value
+func (q *QueryBuilder) CMC(value float64) *QueryBuilder {
+	return q.CMCCompare(Equal, value)
+}
+
+// CMCCompare matches cards whose mana value compares to value using op
+func (q *QueryBuilder) CMCCompare(op Comparison, value float64) *QueryBuilder {
+	return q.keyword("cmc", string(op), strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// Rarity matches cards by rarity, e.g. Rarity(AtLeast, Rare) for r>=rare
+func (q *QueryBuilder) Rarity(op Comparison, rarity Rarity) *QueryBuilder {
+	return q.keyword("r", string(op), string(rarity))
+}
+
+// Set matches cards printed in the set with the given code
+func (q *QueryBuilder) Set(code string) *QueryBuilder {
+	return q.keyword("s", ":", code)
+}
+
+// Type matches cards with typeLine somewhere in their type line
+func (q *QueryBuilder) Type(typeLine string) *QueryBuilder {
+	return q.keyword("t", ":", typeLine)
+}
+
+// OracleText matches cards whose Oracle text contains text
+func (q *QueryBuilder) OracleText(text string) *QueryBuilder {
+	return q.keyword("o", ":", text)
+}
+
+// Game matches cards available in game ("paper", "mtgo" or "arena")
+func (q *QueryBuilder) Game(game string) *QueryBuilder {
+	return q.keyword("game", ":", game)
+}
+
+// In matches cards that have ever been printed in a game, set type or at a rarity, e.g. In("common")
+func (q *QueryBuilder) In(value string) *QueryBuilder {
+	return q.keyword("in", ":", value)
+}
+
+// Legal matches cards legal in the given format, e.g. "modern"
+func (q *QueryBuilder) Legal(format string) *QueryBuilder {
+	return q.keyword("f", ":", format)
+}
+
+// Is matches cards with the given is: flag, e.g. "foil" or "reserved"
+func (q *QueryBuilder) Is(flag string) *QueryBuilder {
+	return q.keyword("is", ":", flag)
+}
+
+// Raw appends term verbatim, for syntax the builder does not cover
+func (q *QueryBuilder) Raw(term string) *QueryBuilder {
+	return q.add(term)
+}
+
+// Or appends a parenthesized group matching any of the given builders
+func (q *QueryBuilder) Or(groups ...*QueryBuilder) *QueryBuilder {
+	var parts []string
+	for _, group := range groups {
+		if built := group.Build(); built != "" {
+			parts = append(parts, built)
+		}
+	}
+	if len(parts) == 0 {
+		q.negate = false
+		return q
+	}
+	return q.add("(" + strings.Join(parts, " or ") + ")")
+}
+
+// Group appends the terms of other as a single parenthesized term, so Not() applies to all of them
+func (q *QueryBuilder) Group(other *QueryBuilder) *QueryBuilder {
+	built := other.Build()
+	if built == "" {
+		q.negate = false
+		return q
+	}
+	return q.add("(" + built + ")")
+}
+
+// Build returns the query string, ready to pass to SearchCardsByQuery
+func (q *QueryBuilder) Build() string {
+	return strings.Join(q.terms, " ")
+}
+
+func (q *QueryBuilder) keyword(name, op, value string) *QueryBuilder {
+	return q.add(name + op + quoteQueryValue(value))
+}
+
+func (q *QueryBuilder) add(term string) *QueryBuilder {
+	if q.negate {
+		term = "-" + term
+		q.negate = false
+	}
+	q.terms = append(q.terms, term)
+	return q
+}
+
+// quoteQueryValue wraps value in double quotes when it contains characters
+// that Scryfall's query parser would otherwise treat as syntax
+func quoteQueryValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\n\"'():<>=!-") {
+		return value
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return `"` + escaped + `"`
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// ResolveRelated fetches the full Card for every entry in card.AllParts, grouped by
+// Component (ComponentToken, ComponentMeldPart, ...). The card's own entry is skipped
+// and each related card is fetched once, through /cards/collection.
+func (c *Client) ResolveRelated(card *Card) (map[Component][]Card, error) {
+	return c.resolveParts(card, func(RelatedCard) bool { return true })
+}
+
+// ComboPieces fetches the cards card.AllParts lists as combo pieces. A card with
+// none returns an empty slice.
+func (c *Client) ComboPieces(card *Card) ([]Card, error) {
+	related, err := c.resolveParts(card, func(part RelatedCard) bool {
+		return part.ComponentKind() == ComponentComboPiece
+	})
+	if err != nil {
+		return nil, err
+	}
+	if related[ComponentComboPiece] == nil {
+		return []Card{}, nil
+	}
+	return related[ComponentComboPiece], nil
+}
+
+// resolveParts is ResolveRelated for just the parts keep accepts
+func (c *Client) resolveParts(card *Card, keep func(RelatedCard) bool) (map[Component][]Card, error) {
+	components := make(map[string]Component) // related card id -> component
+	var identifiers []CardIdentifier
+	for _, part := range card.AllParts {
+		if part.ID == card.ID || !keep(part) {
+			continue
+		}
+		if _, seen := components[part.ID]; seen {
+			continue
+		}
+		components[part.ID] = part.ComponentKind()
+		identifiers = append(identifiers, CardIdentifier{ID: part.ID})
+	}
+
+	related := make(map[Component][]Card)
+	if len(identifiers) == 0 {
+		return related, nil
+	}
+
+	list, _, err := c.getCollection(context.Background(), identifiers)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving cards related to %s: %v", card.Name, err)
+	}
+
+	for _, relatedCard := range list.Data {
+		component := components[relatedCard.ID]
+		related[component] = append(related[component], relatedCard)
+	}
+	return related, nil
+}
+
+// MeldParts splits a meld card's AllParts into the two front cards and the card
+// they meld into. ok is false for cards that aren't meld layout or whose parts
+// are incomplete.
+func (c *Card) MeldParts() (front []RelatedCard, result *RelatedCard, ok bool) {
+	if c.LayoutKind() != LayoutMeld {
+		return nil, nil, false
+	}
+
+	for i, part := range c.AllParts {
+		switch part.ComponentKind() {
+		case ComponentMeldPart:
+			front = append(front, part)
+		case ComponentMeldResult:
+			result = &c.AllParts[i]
+		}
+	}
+	if len(front) != 2 || result == nil {
+		return nil, nil, false
+	}
+	return front, result, true
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// relatedServer answers /cards/collection with a card for each requested ID from cards
+type relatedServer struct {
+	cards map[string]Card
+}
+
+func (s *relatedServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Identifiers []CardIdentifier `json:"identifiers"`
+	}
+	if r.URL.Path != "/cards/collection" || json.NewDecoder(r.Body).Decode(&body) != nil {
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+		return
+	}
+	list := List{Object: "list", Data: []Card{}}
+	for _, id := range body.Identifiers {
+		if card, ok := s.cards[id.ID]; ok {
+			list.Data = append(list.Data, card)
+		} else {
+			list.NotFound = append(list.NotFound, id)
+		}
+	}
+	json.NewEncoder(w).Encode(list)
+}
+
+// A card's own entry is skipped by ID, not by name: a token copy of a card shares its name
+func TestResolveRelatedSameNamedToken(t *testing.T) {
+	card := &Card{
+		ID:   "00000000-0000-4000-8000-000000000001",
+		Name: "Scute Swarm",
+		AllParts: []RelatedCard{
+			{ID: "00000000-0000-4000-8000-000000000001", Component: "combo_piece", Name: "Scute Swarm"},
+			{ID: "00000000-0000-4000-8000-000000000002", Component: "token", Name: "Scute Swarm"},
+			{ID: "00000000-0000-4000-8000-000000000003", Component: "combo_piece", Name: "Doubling Season"},
+		},
+	}
+	server := &relatedServer{cards: map[string]Card{
+		"00000000-0000-4000-8000-000000000001": {ID: "00000000-0000-4000-8000-000000000001", Name: "Scute Swarm"},
+		"00000000-0000-4000-8000-000000000002": {ID: "00000000-0000-4000-8000-000000000002", Name: "Scute Swarm", Layout: "token"},
+		"00000000-0000-4000-8000-000000000003": {ID: "00000000-0000-4000-8000-000000000003", Name: "Doubling Season"},
+	}}
+	client := newTestClient(t, server)
+
+	related, err := client.ResolveRelated(card)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokens := related[ComponentToken]; len(tokens) != 1 || tokens[0].ID != card.AllParts[1].ID {
+		t.Errorf("tokens = %+v, want the same-named token", tokens)
+	}
+	if combos := cardNames(related[ComponentComboPiece]); len(combos) != 1 || combos[0] != "Doubling Season" {
+		t.Errorf("combo pieces = %v, want [Doubling Season] without the card itself", combos)
+	}
+}
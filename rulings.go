@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/ninesl/scryfall-api/scryfall"
+)
+
+// GetRulings fetches the rulings for card from its RulingsURI, or by Scryfall ID when
+// the card has none
+func (c *Client) GetRulings(card *Card) ([]Ruling, error) {
+	endpoint := "/cards/" + url.PathEscape(card.ID) + "/rulings"
+	if card.RulingsURI.String() != "" {
+		var err error
+		if endpoint, err = c.apiEndpoint(card.RulingsURI.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	var list struct {
+		Data []Ruling `json:"data"`
+	}
+	if err := c.makeRequest(endpoint, &list); err != nil {
+		return nil, fmt.Errorf("error fetching rulings for %s: %v", card.Name, err)
+	}
+	return list.Data, nil
+}
+
+// FetchAndStoreRulings fetches the rulings for card and replaces any stored for its
+// oracle card, so a ruling Scryfall has since removed doesn't linger
+func (c *Client) FetchAndStoreRulings(card *Card) error {
+	oracleID, ok := card.oracleID()
+	if !ok {
+		return fmt.Errorf("card %s has no oracle_id", card.Name)
+	}
+	if _, err := c.queries(); err != nil {
+		return err
+	}
+
+	rulings, err := c.GetRulings(card)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	return c.WithTx(ctx, func(queries *scryfall.Queries) error {
+		if err := queries.DeleteRulingsByOracleID(ctx, oracleID); err != nil {
+			return fmt.Errorf("error clearing rulings for %s: %v", card.Name, err)
+		}
+		for _, ruling := range rulings {
+			err := queries.UpsertRuling(ctx, scryfall.UpsertRulingParams{
+				OracleID:    oracleID,
+				Source:      ruling.Source,
+				PublishedAt: ruling.PublishedAt,
+				Comment:     ruling.Comment,
+			})
+			if err != nil {
+				return fmt.Errorf("error storing rulings for %s: %v", card.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetStoredRulings returns the rulings stored for the card with oracleID, oldest first
+func (c *Client) GetStoredRulings(oracleID string) ([]Ruling, error) {
+	queries, err := c.queries()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := queries.GetRulingsByOracleID(context.Background(), oracleID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading rulings: %v", err)
+	}
+
+	rulings := make([]Ruling, len(rows))
+	for i, row := range rows {
+		rulings[i] = Ruling{
+			Object:      "ruling",
+			OracleID:    row.OracleID,
+			Source:      row.Source,
+			PublishedAt: row.PublishedAt,
+			Comment:     row.Comment,
+		}
+	}
+	return rulings, nil
+}
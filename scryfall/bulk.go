@@ -0,0 +1,119 @@
+package scryfall
+
+// Hand-written batch helpers built on the sqlc-generated single-row queries.
+// Keep this file in sync when UpsertPrinting's columns change; bulk_test.go checks it
+// against the generated query.
+
+import (
+	"context"
+	"strings"
+)
+
+// upsertPrintingsBatchSize keeps a batch well under SQLite's 32766 bound-parameter limit
+const upsertPrintingsBatchSize = 250
+
+// upsertPrintingColumns is the number of parameters UpsertPrinting binds per row
+const upsertPrintingColumns = 62
+
+// UpsertPrintings inserts or updates many printings, upsertPrintingsBatchSize rows per statement.
+// It is equivalent to calling UpsertPrinting for each arg in order.
+func (q *Queries) UpsertPrintings(ctx context.Context, args []UpsertPrintingParams) error {
+	for start := 0; start < len(args); start += upsertPrintingsBatchSize {
+		end := start + upsertPrintingsBatchSize
+		if end > len(args) {
+			end = len(args)
+		}
+		batch := args[start:end]
+
+		params := make([]interface{}, 0, len(batch)*upsertPrintingColumns)
+		for _, arg := range batch {
+			params = append(params, upsertPrintingArgs(arg)...)
+		}
+		if _, err := q.db.ExecContext(ctx, multiRowUpsert(upsertPrinting, len(batch)), params...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// multiRowUpsert rewrites a single-row "INSERT ... VALUES (...) ON CONFLICT" query
+// so its VALUES clause repeats once per row
+func multiRowUpsert(query string, rows int) string {
+	valuesStart := strings.Index(query, "VALUES (") + len("VALUES ")
+	valuesEnd := strings.Index(query, ")\nON CONFLICT") + 1
+	row := query[valuesStart:valuesEnd]
+
+	values := make([]string, rows)
+	for i := range values {
+		values[i] = row
+	}
+	return query[:valuesStart] + strings.Join(values, ",\n") + query[valuesEnd:]
+}
+
+// upsertPrintingArgs lists arg's fields in the order upsertPrinting binds them
+func upsertPrintingArgs(arg UpsertPrintingParams) []interface{} {
+	return []interface{}{
+		arg.ID,
+		arg.OracleID,
+		arg.ArenaID,
+		arg.Lang,
+		arg.MtgoID,
+		arg.MtgoFoilID,
+		arg.MultiverseIds,
+		arg.TcgplayerID,
+		arg.TcgplayerEtchedID,
+		arg.CardmarketID,
+		arg.Object,
+		arg.ScryfallUri,
+		arg.Uri,
+		arg.Artist,
+		arg.ArtistIds,
+		arg.AttractionLights,
+		arg.Booster,
+		arg.BorderColor,
+		arg.CardBackID,
+		arg.CollectorNumber,
+		arg.ContentWarning,
+		arg.Digital,
+		arg.Finishes,
+		arg.FlavorName,
+		arg.FlavorText,
+		arg.Foil,
+		arg.Nonfoil,
+		arg.FrameEffects,
+		arg.Frame,
+		arg.FullArt,
+		arg.Games,
+		arg.HighresImage,
+		arg.IllustrationID,
+		arg.ImageStatus,
+		arg.ImageUris,
+		arg.Oversized,
+		arg.Prices,
+		arg.PrintedName,
+		arg.PrintedText,
+		arg.PrintedTypeLine,
+		arg.Promo,
+		arg.PromoTypes,
+		arg.PurchaseUris,
+		arg.Rarity,
+		arg.RelatedUris,
+		arg.ReleasedAt,
+		arg.Reprint,
+		arg.ScryfallSetUri,
+		arg.SetName,
+		arg.SetSearchUri,
+		arg.SetType,
+		arg.SetUri,
+		arg.Set,
+		arg.SetID,
+		arg.StorySpotlight,
+		arg.Textless,
+		arg.Variation,
+		arg.VariationOf,
+		arg.SecurityStamp,
+		arg.Watermark,
+		arg.Preview,
+		arg.RawJson,
+	}
+}
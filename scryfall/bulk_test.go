@@ -0,0 +1,219 @@
+package scryfall
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+// openTestDB creates a SQLite file in a temp dir with schema.sql and every migration applied
+func openTestDB(tb testing.TB) *sql.DB {
+	tb.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(tb.TempDir(), "test.db"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { db.Close() })
+
+	migrations, err := filepath.Glob(filepath.Join("..", "migrations", "*.sql"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	for _, file := range append([]string{filepath.Join("..", "schema.sql")}, migrations...) {
+		ddl, err := os.ReadFile(file)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		if _, err := db.Exec(string(ddl)); err != nil {
+			tb.Fatalf("applying %s: %v", file, err)
+		}
+	}
+	return db
+}
+
+// testPrinting returns params for printing number row where every field holds a value
+// unique to that field and row, so binding any two columns in the wrong order shows up
+func testPrinting(row int) UpsertPrintingParams {
+	var arg UpsertPrintingParams
+	value := reflect.ValueOf(&arg).Elem()
+	for i := range value.NumField() {
+		field := value.Field(i)
+		name := value.Type().Field(i).Name
+		n := int64(row*1000 + i)
+		switch field.Interface().(type) {
+		case string:
+			field.SetString(fmt.Sprintf("%s-%d", name, row))
+		case bool:
+			field.SetBool((row+i)%2 == 0)
+		case sql.NullString:
+			// leave every third row's nullable columns NULL
+			field.Set(reflect.ValueOf(sql.NullString{String: fmt.Sprintf("%s-%d", name, row), Valid: row%3 != 0}))
+		case sql.NullInt64:
+			field.Set(reflect.ValueOf(sql.NullInt64{Int64: n, Valid: row%3 != 0}))
+		case sql.NullBool:
+			field.Set(reflect.ValueOf(sql.NullBool{Bool: (row+i)%2 == 0, Valid: row%3 != 0}))
+		default:
+			panic(fmt.Sprintf("testPrinting: unhandled type %s for %s", field.Type(), name))
+		}
+	}
+	arg.ID = fmt.Sprintf("printing-%05d", row)
+	arg.OracleID = fmt.Sprintf("oracle-%05d", row/2)
+	return arg
+}
+
+// dumpPrintings returns every printings row, ordered by id, as strings for comparison
+func dumpPrintings(t *testing.T, db *sql.DB) [][]string {
+	t.Helper()
+	rows, err := db.Query(`SELECT * FROM printings ORDER BY id`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dump [][]string
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			t.Fatal(err)
+		}
+		row := make([]string, len(columns))
+		for i, v := range values {
+			row[i] = columns[i] + "=NULL"
+			if v.Valid {
+				row[i] = columns[i] + "=" + v.String
+			}
+		}
+		dump = append(dump, row)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return dump
+}
+
+// upsertPrintingArgs and upsertPrintingColumns are kept by hand, so check them
+// against the generated params struct and query
+func TestUpsertPrintingArgsMatchQuery(t *testing.T) {
+	fields := reflect.TypeOf(UpsertPrintingParams{}).NumField()
+	if got := len(upsertPrintingArgs(UpsertPrintingParams{})); got != fields {
+		t.Errorf("upsertPrintingArgs returns %d values, UpsertPrintingParams has %d fields", got, fields)
+	}
+	if fields != upsertPrintingColumns {
+		t.Errorf("upsertPrintingColumns = %d, UpsertPrintingParams has %d fields", upsertPrintingColumns, fields)
+	}
+
+	// a single row rewritten by multiRowUpsert must be the generated query unchanged
+	if got := multiRowUpsert(upsertPrinting, 1); got != upsertPrinting {
+		t.Errorf("multiRowUpsert(upsertPrinting, 1) changed the query:\n%s", got)
+	}
+	three := multiRowUpsert(upsertPrinting, 3)
+	if got, want := strings.Count(three, "?"), 3*strings.Count(upsertPrinting, "?"); got != want {
+		t.Errorf("multiRowUpsert(upsertPrinting, 3) has %d placeholders, want %d", got, want)
+	}
+	if strings.Count(three, "ON CONFLICT") != 1 {
+		t.Errorf("multiRowUpsert(upsertPrinting, 3) repeated the ON CONFLICT clause:\n%s", three)
+	}
+}
+
+// UpsertPrintings must store exactly what UpsertPrinting row by row does, both for new
+// rows and for updates, across several batches
+func TestUpsertPrintingsMatchesUpsertPrinting(t *testing.T) {
+	ctx := context.Background()
+	count := 2*upsertPrintingsBatchSize + 7
+
+	args := make([]UpsertPrintingParams, count)
+	for i := range args {
+		args[i] = testPrinting(i)
+	}
+	// update half the rows with another row's values, keeping their ids
+	updates := make([]UpsertPrintingParams, 0, count/2)
+	for i := 0; i < count; i += 2 {
+		update := testPrinting(count + i)
+		update.ID = args[i].ID
+		updates = append(updates, update)
+	}
+
+	batched, single := openTestDB(t), openTestDB(t)
+	// one transaction each, or the single-row side spends minutes syncing every insert
+	batchedTx, err := batched.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	singleTx, err := single.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pass := range [][]UpsertPrintingParams{args, updates} {
+		if err := New(batchedTx).UpsertPrintings(ctx, pass); err != nil {
+			t.Fatal(err)
+		}
+		for _, arg := range pass {
+			if err := New(singleTx).UpsertPrinting(ctx, arg); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := batchedTx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := singleTx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, want := dumpPrintings(t, batched), dumpPrintings(t, single)
+	if len(got) != count {
+		t.Fatalf("UpsertPrintings stored %d rows, want %d", len(got), count)
+	}
+	if !reflect.DeepEqual(got, want) {
+		for i := range got {
+			if !reflect.DeepEqual(got[i], want[i]) {
+				t.Fatalf("row %d differs:\nbatched: %v\nsingle:  %v", i, got[i], want[i])
+			}
+		}
+	}
+}
+
+// BenchmarkUpsertPrintings stores 500 printings per op into a SQLite file, outside a
+// transaction as the crawl does, batched and one statement per row. RowByRow syncs
+// every insert, so expect it to take tens of seconds per op.
+func BenchmarkUpsertPrintings(b *testing.B) {
+	ctx := context.Background()
+	args := make([]UpsertPrintingParams, 500)
+	for i := range args {
+		args[i] = testPrinting(i)
+	}
+
+	b.Run("Batched", func(b *testing.B) {
+		queries := New(openTestDB(b))
+		for b.Loop() {
+			if err := queries.UpsertPrintings(ctx, args); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("RowByRow", func(b *testing.B) {
+		queries := New(openTestDB(b))
+		for b.Loop() {
+			for _, arg := range args {
+				if err := queries.UpsertPrinting(ctx, arg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
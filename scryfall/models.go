@@ -100,4 +100,12 @@ type Printing struct {
 	SecurityStamp     sql.NullString
 	Watermark         sql.NullString
 	Preview           sql.NullString
+	RawJson           sql.NullString
+}
+
+type Ruling struct {
+	OracleID    string
+	Source      string
+	PublishedAt string
+	Comment     string
 }
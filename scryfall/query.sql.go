@@ -10,6 +10,373 @@ import (
 	"database/sql"
 )
 
+const countCards = `-- name: CountCards :one
+SELECT COUNT(DISTINCT c.oracle_id) FROM cards c
+JOIN printings p ON c.oracle_id = p.oracle_id
+`
+
+// Count the stored cards (oracle-level) that have at least one printing
+func (q *Queries) CountCards(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countCards)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteRulingsByOracleID = `-- name: DeleteRulingsByOracleID :exec
+DELETE FROM rulings
+WHERE oracle_id = ?
+`
+
+// Delete every stored ruling of a card
+func (q *Queries) DeleteRulingsByOracleID(ctx context.Context, oracleID string) error {
+	_, err := q.db.ExecContext(ctx, deleteRulingsByOracleID, oracleID)
+	return err
+}
+
+const findCardsByName = `-- name: FindCardsByName :many
+SELECT oracle_id, name, layout, prints_search_uri, rulings_uri, all_parts, card_faces, cmc, color_identity, color_indicator, colors, defense, edhrec_rank, game_changer, hand_modifier, keywords, legalities, life_modifier, loyalty, mana_cost, oracle_text, penny_rank, power, produced_mana, reserved, toughness, type_line FROM cards
+WHERE name LIKE ?1 ESCAPE '\'
+ORDER BY name
+`
+
+// Get cards whose name matches a LIKE pattern, case-insensitively
+func (q *Queries) FindCardsByName(ctx context.Context, pattern string) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, findCardsByName, pattern)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Card
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.OracleID,
+			&i.Name,
+			&i.Layout,
+			&i.PrintsSearchUri,
+			&i.RulingsUri,
+			&i.AllParts,
+			&i.CardFaces,
+			&i.Cmc,
+			&i.ColorIdentity,
+			&i.ColorIndicator,
+			&i.Colors,
+			&i.Defense,
+			&i.EdhrecRank,
+			&i.GameChanger,
+			&i.HandModifier,
+			&i.Keywords,
+			&i.Legalities,
+			&i.LifeModifier,
+			&i.Loyalty,
+			&i.ManaCost,
+			&i.OracleText,
+			&i.PennyRank,
+			&i.Power,
+			&i.ProducedMana,
+			&i.Reserved,
+			&i.Toughness,
+			&i.TypeLine,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCard = `-- name: GetCard :one
+SELECT oracle_id, name, layout, prints_search_uri, rulings_uri, all_parts, card_faces, cmc, color_identity, color_indicator, colors, defense, edhrec_rank, game_changer, hand_modifier, keywords, legalities, life_modifier, loyalty, mana_cost, oracle_text, penny_rank, power, produced_mana, reserved, toughness, type_line FROM cards
+WHERE oracle_id = ?
+`
+
+// Get a single card (oracle-level)
+func (q *Queries) GetCard(ctx context.Context, oracleID string) (Card, error) {
+	row := q.db.QueryRowContext(ctx, getCard, oracleID)
+	var i Card
+	err := row.Scan(
+		&i.OracleID,
+		&i.Name,
+		&i.Layout,
+		&i.PrintsSearchUri,
+		&i.RulingsUri,
+		&i.AllParts,
+		&i.CardFaces,
+		&i.Cmc,
+		&i.ColorIdentity,
+		&i.ColorIndicator,
+		&i.Colors,
+		&i.Defense,
+		&i.EdhrecRank,
+		&i.GameChanger,
+		&i.HandModifier,
+		&i.Keywords,
+		&i.Legalities,
+		&i.LifeModifier,
+		&i.Loyalty,
+		&i.ManaCost,
+		&i.OracleText,
+		&i.PennyRank,
+		&i.Power,
+		&i.ProducedMana,
+		&i.Reserved,
+		&i.Toughness,
+		&i.TypeLine,
+	)
+	return i, err
+}
+
+const getCardsByArtist = `-- name: GetCardsByArtist :many
+SELECT id, oracle_id, arena_id, lang, mtgo_id, mtgo_foil_id, multiverse_ids, tcgplayer_id, tcgplayer_etched_id, cardmarket_id, object, scryfall_uri, uri, artist, artist_ids, attraction_lights, booster, border_color, card_back_id, collector_number, content_warning, digital, finishes, flavor_name, flavor_text, foil, nonfoil, frame_effects, frame, full_art, games, highres_image, illustration_id, image_status, image_uris, oversized, prices, printed_name, printed_text, printed_type_line, promo, promo_types, purchase_uris, rarity, related_uris, released_at, reprint, scryfall_set_uri, set_name, set_search_uri, set_type, set_uri, "set", set_id, story_spotlight, textless, variation, variation_of, security_stamp, watermark, preview, raw_json FROM printings
+WHERE LOWER(artist) = LOWER(?1)
+ORDER BY released_at DESC, "set", collector_number
+`
+
+// Get all printings illustrated by an artist, matched case-insensitively, newest first
+func (q *Queries) GetCardsByArtist(ctx context.Context, artist string) ([]Printing, error) {
+	rows, err := q.db.QueryContext(ctx, getCardsByArtist, artist)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Printing
+	for rows.Next() {
+		var i Printing
+		if err := rows.Scan(
+			&i.ID,
+			&i.OracleID,
+			&i.ArenaID,
+			&i.Lang,
+			&i.MtgoID,
+			&i.MtgoFoilID,
+			&i.MultiverseIds,
+			&i.TcgplayerID,
+			&i.TcgplayerEtchedID,
+			&i.CardmarketID,
+			&i.Object,
+			&i.ScryfallUri,
+			&i.Uri,
+			&i.Artist,
+			&i.ArtistIds,
+			&i.AttractionLights,
+			&i.Booster,
+			&i.BorderColor,
+			&i.CardBackID,
+			&i.CollectorNumber,
+			&i.ContentWarning,
+			&i.Digital,
+			&i.Finishes,
+			&i.FlavorName,
+			&i.FlavorText,
+			&i.Foil,
+			&i.Nonfoil,
+			&i.FrameEffects,
+			&i.Frame,
+			&i.FullArt,
+			&i.Games,
+			&i.HighresImage,
+			&i.IllustrationID,
+			&i.ImageStatus,
+			&i.ImageUris,
+			&i.Oversized,
+			&i.Prices,
+			&i.PrintedName,
+			&i.PrintedText,
+			&i.PrintedTypeLine,
+			&i.Promo,
+			&i.PromoTypes,
+			&i.PurchaseUris,
+			&i.Rarity,
+			&i.RelatedUris,
+			&i.ReleasedAt,
+			&i.Reprint,
+			&i.ScryfallSetUri,
+			&i.SetName,
+			&i.SetSearchUri,
+			&i.SetType,
+			&i.SetUri,
+			&i.Set,
+			&i.SetID,
+			&i.StorySpotlight,
+			&i.Textless,
+			&i.Variation,
+			&i.VariationOf,
+			&i.SecurityStamp,
+			&i.Watermark,
+			&i.Preview,
+			&i.RawJson,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCardsByArtistID = `-- name: GetCardsByArtistID :many
+SELECT id, oracle_id, arena_id, lang, mtgo_id, mtgo_foil_id, multiverse_ids, tcgplayer_id, tcgplayer_etched_id, cardmarket_id, object, scryfall_uri, uri, artist, artist_ids, attraction_lights, booster, border_color, card_back_id, collector_number, content_warning, digital, finishes, flavor_name, flavor_text, foil, nonfoil, frame_effects, frame, full_art, games, highres_image, illustration_id, image_status, image_uris, oversized, prices, printed_name, printed_text, printed_type_line, promo, promo_types, purchase_uris, rarity, related_uris, released_at, reprint, scryfall_set_uri, set_name, set_search_uri, set_type, set_uri, "set", set_id, story_spotlight, textless, variation, variation_of, security_stamp, watermark, preview, raw_json FROM printings
+WHERE EXISTS (SELECT 1 FROM json_each(printings.artist_ids) WHERE json_each.value = ?1)
+ORDER BY released_at DESC, "set", collector_number
+`
+
+// Get all printings credited to an artist's Scryfall id, newest first
+func (q *Queries) GetCardsByArtistID(ctx context.Context, artistID string) ([]Printing, error) {
+	rows, err := q.db.QueryContext(ctx, getCardsByArtistID, artistID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Printing
+	for rows.Next() {
+		var i Printing
+		if err := rows.Scan(
+			&i.ID,
+			&i.OracleID,
+			&i.ArenaID,
+			&i.Lang,
+			&i.MtgoID,
+			&i.MtgoFoilID,
+			&i.MultiverseIds,
+			&i.TcgplayerID,
+			&i.TcgplayerEtchedID,
+			&i.CardmarketID,
+			&i.Object,
+			&i.ScryfallUri,
+			&i.Uri,
+			&i.Artist,
+			&i.ArtistIds,
+			&i.AttractionLights,
+			&i.Booster,
+			&i.BorderColor,
+			&i.CardBackID,
+			&i.CollectorNumber,
+			&i.ContentWarning,
+			&i.Digital,
+			&i.Finishes,
+			&i.FlavorName,
+			&i.FlavorText,
+			&i.Foil,
+			&i.Nonfoil,
+			&i.FrameEffects,
+			&i.Frame,
+			&i.FullArt,
+			&i.Games,
+			&i.HighresImage,
+			&i.IllustrationID,
+			&i.ImageStatus,
+			&i.ImageUris,
+			&i.Oversized,
+			&i.Prices,
+			&i.PrintedName,
+			&i.PrintedText,
+			&i.PrintedTypeLine,
+			&i.Promo,
+			&i.PromoTypes,
+			&i.PurchaseUris,
+			&i.Rarity,
+			&i.RelatedUris,
+			&i.ReleasedAt,
+			&i.Reprint,
+			&i.ScryfallSetUri,
+			&i.SetName,
+			&i.SetSearchUri,
+			&i.SetType,
+			&i.SetUri,
+			&i.Set,
+			&i.SetID,
+			&i.StorySpotlight,
+			&i.Textless,
+			&i.Variation,
+			&i.VariationOf,
+			&i.SecurityStamp,
+			&i.Watermark,
+			&i.Preview,
+			&i.RawJson,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCardsByKeyword = `-- name: GetCardsByKeyword :many
+SELECT oracle_id, name, layout, prints_search_uri, rulings_uri, all_parts, card_faces, cmc, color_identity, color_indicator, colors, defense, edhrec_rank, game_changer, hand_modifier, keywords, legalities, life_modifier, loyalty, mana_cost, oracle_text, penny_rank, power, produced_mana, reserved, toughness, type_line FROM cards
+WHERE EXISTS (SELECT 1 FROM json_each(cards.keywords) WHERE LOWER(json_each.value) = LOWER(?1))
+ORDER BY name
+`
+
+// Get cards with a keyword, matched case-insensitively
+func (q *Queries) GetCardsByKeyword(ctx context.Context, keyword string) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, getCardsByKeyword, keyword)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Card
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.OracleID,
+			&i.Name,
+			&i.Layout,
+			&i.PrintsSearchUri,
+			&i.RulingsUri,
+			&i.AllParts,
+			&i.CardFaces,
+			&i.Cmc,
+			&i.ColorIdentity,
+			&i.ColorIndicator,
+			&i.Colors,
+			&i.Defense,
+			&i.EdhrecRank,
+			&i.GameChanger,
+			&i.HandModifier,
+			&i.Keywords,
+			&i.Legalities,
+			&i.LifeModifier,
+			&i.Loyalty,
+			&i.ManaCost,
+			&i.OracleText,
+			&i.PennyRank,
+			&i.Power,
+			&i.ProducedMana,
+			&i.Reserved,
+			&i.Toughness,
+			&i.TypeLine,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getCardsWithPrintings = `-- name: GetCardsWithPrintings :many
 SELECT 
     c.oracle_id,
@@ -29,7 +396,7 @@ SELECT
     p.released_at
 FROM cards c
 JOIN printings p ON c.oracle_id = p.oracle_id
-ORDER BY c.name, p.released_at DESC
+ORDER BY c.name, c.oracle_id, p.released_at DESC, p.id
 `
 
 type GetCardsWithPrintingsRow struct {
@@ -90,6 +457,500 @@ func (q *Queries) GetCardsWithPrintings(ctx context.Context) ([]GetCardsWithPrin
 	return items, nil
 }
 
+const getCardsWithPrintingsPaged = `-- name: GetCardsWithPrintingsPaged :many
+SELECT 
+    c.oracle_id,
+    c.name,
+    c.layout,
+    c.cmc,
+    c.color_identity,
+    c.colors,
+    c.mana_cost,
+    c.oracle_text,
+    c.type_line,
+    p.id as printing_id,
+    p.rarity,
+    p.games,
+    p."set",
+    p.set_name,
+    p.released_at
+FROM cards c
+JOIN printings p ON c.oracle_id = p.oracle_id
+WHERE c.oracle_id IN (
+    SELECT DISTINCT pc.oracle_id FROM cards pc
+    JOIN printings pp ON pc.oracle_id = pp.oracle_id
+    ORDER BY pc.name, pc.oracle_id
+    LIMIT ? OFFSET ?
+)
+ORDER BY c.name, c.oracle_id, p.released_at DESC, p.id
+`
+
+type GetCardsWithPrintingsPagedRow struct {
+	OracleID      string
+	Name          string
+	Layout        string
+	Cmc           float64
+	ColorIdentity string
+	Colors        sql.NullString
+	ManaCost      sql.NullString
+	OracleText    sql.NullString
+	TypeLine      string
+	PrintingID    string
+	Rarity        string
+	Games         string
+	Set           string
+	SetName       string
+	ReleasedAt    string
+}
+
+type GetCardsWithPrintingsPagedParams struct {
+	Limit  int64
+	Offset int64
+}
+
+// Get one page of cards that have printings, ordered by name, with all of their printings
+func (q *Queries) GetCardsWithPrintingsPaged(ctx context.Context, arg GetCardsWithPrintingsPagedParams) ([]GetCardsWithPrintingsPagedRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCardsWithPrintingsPaged,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCardsWithPrintingsPagedRow
+	for rows.Next() {
+		var i GetCardsWithPrintingsPagedRow
+		if err := rows.Scan(
+			&i.OracleID,
+			&i.Name,
+			&i.Layout,
+			&i.Cmc,
+			&i.ColorIdentity,
+			&i.Colors,
+			&i.ManaCost,
+			&i.OracleText,
+			&i.TypeLine,
+			&i.PrintingID,
+			&i.Rarity,
+			&i.Games,
+			&i.Set,
+			&i.SetName,
+			&i.ReleasedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOracleCards = `-- name: GetOracleCards :many
+SELECT oracle_id, name, layout, prints_search_uri, rulings_uri, all_parts, card_faces, cmc, color_identity, color_indicator, colors, defense, edhrec_rank, game_changer, hand_modifier, keywords, legalities, life_modifier, loyalty, mana_cost, oracle_text, penny_rank, power, produced_mana, reserved, toughness, type_line FROM cards
+ORDER BY name
+`
+
+// Get every card once, oracle-level fields only
+func (q *Queries) GetOracleCards(ctx context.Context) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, getOracleCards)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Card
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.OracleID,
+			&i.Name,
+			&i.Layout,
+			&i.PrintsSearchUri,
+			&i.RulingsUri,
+			&i.AllParts,
+			&i.CardFaces,
+			&i.Cmc,
+			&i.ColorIdentity,
+			&i.ColorIndicator,
+			&i.Colors,
+			&i.Defense,
+			&i.EdhrecRank,
+			&i.GameChanger,
+			&i.HandModifier,
+			&i.Keywords,
+			&i.Legalities,
+			&i.LifeModifier,
+			&i.Loyalty,
+			&i.ManaCost,
+			&i.OracleText,
+			&i.PennyRank,
+			&i.Power,
+			&i.ProducedMana,
+			&i.Reserved,
+			&i.Toughness,
+			&i.TypeLine,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPrintingRawJSON = `-- name: GetPrintingRawJSON :one
+SELECT raw_json FROM printings
+WHERE id = ?
+`
+
+// Get the raw Scryfall JSON stored for a printing
+func (q *Queries) GetPrintingRawJSON(ctx context.Context, id string) (sql.NullString, error) {
+	row := q.db.QueryRowContext(ctx, getPrintingRawJSON, id)
+	var raw_json sql.NullString
+	err := row.Scan(&raw_json)
+	return raw_json, err
+}
+
+const getPrintingsByOracleID = `-- name: GetPrintingsByOracleID :many
+SELECT id, oracle_id, arena_id, lang, mtgo_id, mtgo_foil_id, multiverse_ids, tcgplayer_id, tcgplayer_etched_id, cardmarket_id, object, scryfall_uri, uri, artist, artist_ids, attraction_lights, booster, border_color, card_back_id, collector_number, content_warning, digital, finishes, flavor_name, flavor_text, foil, nonfoil, frame_effects, frame, full_art, games, highres_image, illustration_id, image_status, image_uris, oversized, prices, printed_name, printed_text, printed_type_line, promo, promo_types, purchase_uris, rarity, related_uris, released_at, reprint, scryfall_set_uri, set_name, set_search_uri, set_type, set_uri, "set", set_id, story_spotlight, textless, variation, variation_of, security_stamp, watermark, preview, raw_json FROM printings
+WHERE oracle_id = ?
+ORDER BY released_at DESC
+`
+
+// Get all printings of a card, newest first
+func (q *Queries) GetPrintingsByOracleID(ctx context.Context, oracleID string) ([]Printing, error) {
+	rows, err := q.db.QueryContext(ctx, getPrintingsByOracleID, oracleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Printing
+	for rows.Next() {
+		var i Printing
+		if err := rows.Scan(
+			&i.ID,
+			&i.OracleID,
+			&i.ArenaID,
+			&i.Lang,
+			&i.MtgoID,
+			&i.MtgoFoilID,
+			&i.MultiverseIds,
+			&i.TcgplayerID,
+			&i.TcgplayerEtchedID,
+			&i.CardmarketID,
+			&i.Object,
+			&i.ScryfallUri,
+			&i.Uri,
+			&i.Artist,
+			&i.ArtistIds,
+			&i.AttractionLights,
+			&i.Booster,
+			&i.BorderColor,
+			&i.CardBackID,
+			&i.CollectorNumber,
+			&i.ContentWarning,
+			&i.Digital,
+			&i.Finishes,
+			&i.FlavorName,
+			&i.FlavorText,
+			&i.Foil,
+			&i.Nonfoil,
+			&i.FrameEffects,
+			&i.Frame,
+			&i.FullArt,
+			&i.Games,
+			&i.HighresImage,
+			&i.IllustrationID,
+			&i.ImageStatus,
+			&i.ImageUris,
+			&i.Oversized,
+			&i.Prices,
+			&i.PrintedName,
+			&i.PrintedText,
+			&i.PrintedTypeLine,
+			&i.Promo,
+			&i.PromoTypes,
+			&i.PurchaseUris,
+			&i.Rarity,
+			&i.RelatedUris,
+			&i.ReleasedAt,
+			&i.Reprint,
+			&i.ScryfallSetUri,
+			&i.SetName,
+			&i.SetSearchUri,
+			&i.SetType,
+			&i.SetUri,
+			&i.Set,
+			&i.SetID,
+			&i.StorySpotlight,
+			&i.Textless,
+			&i.Variation,
+			&i.VariationOf,
+			&i.SecurityStamp,
+			&i.Watermark,
+			&i.Preview,
+			&i.RawJson,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPrintingsBySet = `-- name: GetPrintingsBySet :many
+SELECT id, oracle_id, arena_id, lang, mtgo_id, mtgo_foil_id, multiverse_ids, tcgplayer_id, tcgplayer_etched_id, cardmarket_id, object, scryfall_uri, uri, artist, artist_ids, attraction_lights, booster, border_color, card_back_id, collector_number, content_warning, digital, finishes, flavor_name, flavor_text, foil, nonfoil, frame_effects, frame, full_art, games, highres_image, illustration_id, image_status, image_uris, oversized, prices, printed_name, printed_text, printed_type_line, promo, promo_types, purchase_uris, rarity, related_uris, released_at, reprint, scryfall_set_uri, set_name, set_search_uri, set_type, set_uri, "set", set_id, story_spotlight, textless, variation, variation_of, security_stamp, watermark, preview, raw_json FROM printings
+WHERE "set" = ?1
+ORDER BY collector_number
+`
+
+// Get all stored printings from a set
+func (q *Queries) GetPrintingsBySet(ctx context.Context, setCode string) ([]Printing, error) {
+	rows, err := q.db.QueryContext(ctx, getPrintingsBySet, setCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Printing
+	for rows.Next() {
+		var i Printing
+		if err := rows.Scan(
+			&i.ID,
+			&i.OracleID,
+			&i.ArenaID,
+			&i.Lang,
+			&i.MtgoID,
+			&i.MtgoFoilID,
+			&i.MultiverseIds,
+			&i.TcgplayerID,
+			&i.TcgplayerEtchedID,
+			&i.CardmarketID,
+			&i.Object,
+			&i.ScryfallUri,
+			&i.Uri,
+			&i.Artist,
+			&i.ArtistIds,
+			&i.AttractionLights,
+			&i.Booster,
+			&i.BorderColor,
+			&i.CardBackID,
+			&i.CollectorNumber,
+			&i.ContentWarning,
+			&i.Digital,
+			&i.Finishes,
+			&i.FlavorName,
+			&i.FlavorText,
+			&i.Foil,
+			&i.Nonfoil,
+			&i.FrameEffects,
+			&i.Frame,
+			&i.FullArt,
+			&i.Games,
+			&i.HighresImage,
+			&i.IllustrationID,
+			&i.ImageStatus,
+			&i.ImageUris,
+			&i.Oversized,
+			&i.Prices,
+			&i.PrintedName,
+			&i.PrintedText,
+			&i.PrintedTypeLine,
+			&i.Promo,
+			&i.PromoTypes,
+			&i.PurchaseUris,
+			&i.Rarity,
+			&i.RelatedUris,
+			&i.ReleasedAt,
+			&i.Reprint,
+			&i.ScryfallSetUri,
+			&i.SetName,
+			&i.SetSearchUri,
+			&i.SetType,
+			&i.SetUri,
+			&i.Set,
+			&i.SetID,
+			&i.StorySpotlight,
+			&i.Textless,
+			&i.Variation,
+			&i.VariationOf,
+			&i.SecurityStamp,
+			&i.Watermark,
+			&i.Preview,
+			&i.RawJson,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRulingsByOracleID = `-- name: GetRulingsByOracleID :many
+SELECT oracle_id, source, published_at, comment FROM rulings
+WHERE oracle_id = ?
+ORDER BY published_at, source
+`
+
+// Get all rulings of a card, oldest first
+func (q *Queries) GetRulingsByOracleID(ctx context.Context, oracleID string) ([]Ruling, error) {
+	rows, err := q.db.QueryContext(ctx, getRulingsByOracleID, oracleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Ruling
+	for rows.Next() {
+		var i Ruling
+		if err := rows.Scan(
+			&i.OracleID,
+			&i.Source,
+			&i.PublishedAt,
+			&i.Comment,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPrintingIDs = `-- name: ListPrintingIDs :many
+SELECT id FROM printings
+ORDER BY id
+`
+
+// Get the id of every stored printing
+func (q *Queries) ListPrintingIDs(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listPrintingIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchOracleText = `-- name: SearchOracleText :many
+SELECT cards.oracle_id, cards.name, cards.layout, cards.prints_search_uri, cards.rulings_uri, cards.all_parts, cards.card_faces, cards.cmc, cards.color_identity, cards.color_indicator, cards.colors, cards.defense, cards.edhrec_rank, cards.game_changer, cards.hand_modifier, cards.keywords, cards.legalities, cards.life_modifier, cards.loyalty, cards.mana_cost, cards.oracle_text, cards.penny_rank, cards.power, cards.produced_mana, cards.reserved, cards.toughness, cards.type_line FROM cards
+JOIN cards_fts ON cards_fts.oracle_id = cards.oracle_id
+WHERE cards_fts MATCH ?1
+ORDER BY cards_fts.rank
+`
+
+// Full-text search over oracle text, best matches first.
+// match is an FTS5 query, e.g. oracle_text:"draw a card"
+func (q *Queries) SearchOracleText(ctx context.Context, match string) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, searchOracleText, match)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Card
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.OracleID,
+			&i.Name,
+			&i.Layout,
+			&i.PrintsSearchUri,
+			&i.RulingsUri,
+			&i.AllParts,
+			&i.CardFaces,
+			&i.Cmc,
+			&i.ColorIdentity,
+			&i.ColorIndicator,
+			&i.Colors,
+			&i.Defense,
+			&i.EdhrecRank,
+			&i.GameChanger,
+			&i.HandModifier,
+			&i.Keywords,
+			&i.Legalities,
+			&i.LifeModifier,
+			&i.Loyalty,
+			&i.ManaCost,
+			&i.OracleText,
+			&i.PennyRank,
+			&i.Power,
+			&i.ProducedMana,
+			&i.Reserved,
+			&i.Toughness,
+			&i.TypeLine,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updatePrintingPrices = `-- name: UpdatePrintingPrices :exec
+UPDATE printings
+SET prices = ?
+WHERE id = ?
+`
+
+type UpdatePrintingPricesParams struct {
+	Prices string
+	ID     string
+}
+
+// Update only the prices of a printing
+func (q *Queries) UpdatePrintingPrices(ctx context.Context, arg UpdatePrintingPricesParams) error {
+	_, err := q.db.ExecContext(ctx, updatePrintingPrices,
+		arg.Prices,
+		arg.ID,
+	)
+	return err
+}
+
 const upsertCard = `-- name: UpsertCard :exec
 INSERT INTO cards (
     oracle_id, name, layout, prints_search_uri, rulings_uri,
@@ -204,11 +1065,11 @@ INSERT INTO printings (
     printed_text, printed_type_line, promo, promo_types, purchase_uris, rarity,
     related_uris, released_at, reprint, scryfall_set_uri, set_name, set_search_uri,
     set_type, set_uri, "set", set_id, story_spotlight, textless, variation,
-    variation_of, security_stamp, watermark, preview
+    variation_of, security_stamp, watermark, preview, raw_json
 ) VALUES (
     ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
     ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,
-    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 )
 ON CONFLICT(id) DO UPDATE SET
     oracle_id = excluded.oracle_id,
@@ -270,7 +1131,8 @@ ON CONFLICT(id) DO UPDATE SET
     variation_of = excluded.variation_of,
     security_stamp = excluded.security_stamp,
     watermark = excluded.watermark,
-    preview = excluded.preview
+    preview = excluded.preview,
+    raw_json = excluded.raw_json
 `
 
 type UpsertPrintingParams struct {
@@ -335,6 +1197,7 @@ type UpsertPrintingParams struct {
 	SecurityStamp     sql.NullString
 	Watermark         sql.NullString
 	Preview           sql.NullString
+	RawJson           sql.NullString
 }
 
 // Insert or update a printing
@@ -401,6 +1264,34 @@ func (q *Queries) UpsertPrinting(ctx context.Context, arg UpsertPrintingParams)
 		arg.SecurityStamp,
 		arg.Watermark,
 		arg.Preview,
+		arg.RawJson,
+	)
+	return err
+}
+
+const upsertRuling = `-- name: UpsertRuling :exec
+INSERT INTO rulings (
+    oracle_id, source, published_at, comment
+) VALUES (
+    ?, ?, ?, ?
+)
+ON CONFLICT(oracle_id, source, published_at, comment) DO NOTHING
+`
+
+type UpsertRulingParams struct {
+	OracleID    string
+	Source      string
+	PublishedAt string
+	Comment     string
+}
+
+// Insert a ruling, skipping it if it's already stored
+func (q *Queries) UpsertRuling(ctx context.Context, arg UpsertRulingParams) error {
+	_, err := q.db.ExecContext(ctx, upsertRuling,
+		arg.OracleID,
+		arg.Source,
+		arg.PublishedAt,
+		arg.Comment,
 	)
 	return err
 }
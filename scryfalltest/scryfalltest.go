@@ -0,0 +1,136 @@
+// Package scryfalltest serves recorded Scryfall API responses for offline tests.
+//
+// A request is answered from a JSON file in the fixture directory named after its
+// path and query, see FixtureName. Requests with no matching fixture get a
+// Scryfall-shaped 404 error object, so not_found handling can be exercised too.
+//
+// Fixtures are recorded from the live API with Record; to refresh the ones in
+// testdata run
+//
+//	go test ./scryfalltest -run TestRecord -record
+package scryfalltest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// NewServer starts an httptest server answering requests from the fixtures in dir.
+// The caller must Close it.
+func NewServer(dir string) *httptest.Server {
+	return httptest.NewServer(Handler(dir))
+}
+
+// Handler answers requests from the fixtures in dir. A fixture named for the full
+// path and query is preferred, then one named for the path alone.
+func Handler(dir string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		names := []string{FixtureName(r.URL.Path, r.URL.Query())}
+		if r.URL.RawQuery != "" {
+			names = append(names, FixtureName(r.URL.Path, nil))
+		}
+
+		for _, name := range names {
+			data, err := os.ReadFile(filepath.Join(dir, name))
+			if err != nil {
+				continue
+			}
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.Write(data)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"object":"error","code":"not_found","status":404,"details":"No fixture for ` + names[0] + `"}`))
+	})
+}
+
+// FixtureName returns the file name a request is served from: the path with
+// slashes replaced by underscores, then the encoded query if there is one.
+//
+//	/cards/named?exact=Fire+//+Ice -> cards_named@exact=Fire+%2F%2F+Ice.json
+func FixtureName(path string, query url.Values) string {
+	name := strings.ReplaceAll(strings.Trim(path, "/"), "/", "_")
+	if len(query) > 0 {
+		name += "@" + query.Encode()
+	}
+	return name + ".json"
+}
+
+// ParseFixtureName is the inverse of FixtureName, returning the request path and query
+// a fixture answers. Underscores always map back to slashes, which holds for every
+// Scryfall API path.
+func ParseFixtureName(name string) (path string, query url.Values, err error) {
+	name, ok := strings.CutSuffix(name, ".json")
+	if !ok {
+		return "", nil, fmt.Errorf("fixture %q is not a .json file", name)
+	}
+	path, rawQuery, _ := strings.Cut(name, "@")
+	if query, err = url.ParseQuery(rawQuery); err != nil {
+		return "", nil, fmt.Errorf("fixture %q has an invalid query: %v", name, err)
+	}
+	return "/" + strings.ReplaceAll(path, "_", "/"), query, nil
+}
+
+// Record re-fetches every fixture in dir from the API at apiURL (normally
+// https://api.scryfall.com) and overwrites it with the indented response body.
+// Requests are spaced 100ms apart, as Scryfall asks.
+func Record(client *http.Client, apiURL, userAgent, dir string) error {
+	names, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for i, file := range names {
+		if i > 0 {
+			time.Sleep(100 * time.Millisecond)
+		}
+		path, query, err := ParseFixtureName(filepath.Base(file))
+		if err != nil {
+			return err
+		}
+		target := apiURL + path
+		if len(query) > 0 {
+			target += "?" + query.Encode()
+		}
+
+		req, err := http.NewRequest("GET", target, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("User-Agent", userAgent)
+		req.Header.Set("Accept", "application/json")
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("error recording %s: %v", target, err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("error recording %s: %v", target, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("error recording %s: status %d", target, resp.StatusCode)
+		}
+
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, body, "", "  "); err != nil {
+			return fmt.Errorf("error recording %s: %v", target, err)
+		}
+		indented.WriteByte('\n')
+		if err := os.WriteFile(file, indented.Bytes(), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package scryfalltest
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var record = flag.Bool("record", false, "re-record testdata fixtures from the live Scryfall API")
+
+func TestRecord(t *testing.T) {
+	if !*record {
+		t.Skip("run with -record to re-record fixtures from api.scryfall.com")
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	if err := Record(client, "https://api.scryfall.com", "ScryfallTest/1.0", "testdata"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFixtureNameRoundTrip(t *testing.T) {
+	tests := []struct {
+		path  string
+		query url.Values
+	}{
+		{"/cards/search", url.Values{"q": {`!"Fire // Ice"`}}},
+		{"/cards/named", url.Values{"exact": {"Delver of Secrets"}, "set": {"isd"}}},
+		{"/sets/neo", nil},
+		{"/bulk-data", nil},
+	}
+	for _, tt := range tests {
+		name := FixtureName(tt.path, tt.query)
+		path, query, err := ParseFixtureName(name)
+		if err != nil {
+			t.Fatalf("ParseFixtureName(%q): %v", name, err)
+		}
+		if path != tt.path || query.Encode() != tt.query.Encode() {
+			t.Errorf("ParseFixtureName(%q) = %q, %q; want %q, %q", name, path, query.Encode(), tt.path, tt.query.Encode())
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	dir := t.TempDir()
+	fixture := `{"object":"set","code":"neo"}`
+	if err := os.WriteFile(filepath.Join(dir, FixtureName("/sets/neo", nil)), []byte(fixture), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	server := NewServer(dir)
+	defer server.Close()
+
+	get := func(path string) (int, string) {
+		t.Helper()
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	// the path-only fixture answers requests with any query
+	for _, path := range []string{"/sets/neo", "/sets/neo?format=json"} {
+		if status, body := get(path); status != http.StatusOK || body != fixture {
+			t.Errorf("GET %s = %d %s, want 200 %s", path, status, body, fixture)
+		}
+	}
+
+	status, body := get("/sets/zzz")
+	var apiErr struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal([]byte(body), &apiErr); err != nil || status != http.StatusNotFound || apiErr.Code != "not_found" {
+		t.Errorf("GET /sets/zzz = %d %s, want a 404 not_found error object", status, body)
+	}
+}
+
+// every shipped fixture must map back to a request, or Record can't refresh it
+func TestTestdataFixtureNames(t *testing.T) {
+	names, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range names {
+		path, query, err := ParseFixtureName(filepath.Base(file))
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if got := FixtureName(path, query); got != filepath.Base(file) {
+			t.Errorf("fixture %s maps back to %s", filepath.Base(file), got)
+		}
+	}
+}
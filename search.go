@@ -0,0 +1,385 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrNoCardsFound is returned when a search is valid but matches no cards.
+// Scryfall reports this as a 404, which is otherwise indistinguishable from a failure.
+var ErrNoCardsFound = errors.New("no cards found")
+
+// ErrStopSearch can be returned by a SearchCardsFunc callback to stop paging early.
+// SearchCardsFunc then returns nil.
+var ErrStopSearch = errors.New("stop search")
+
+// ErrMaxPagesReached is returned, along with the cards already fetched, when a list
+// has more pages than ClientOptions.MaxPages allows
+var ErrMaxPagesReached = errors.New("maximum page count reached")
+
+// SearchOptions are the optional /cards/search parameters
+type SearchOptions struct {
+	// IncludeExtras includes tokens, emblems, art cards and other extras.
+	// Complete set lists need this, but it can add many results to broad queries.
+	IncludeExtras bool
+
+	// IncludeVariations includes alternate versions of cards (see Card.Variation and
+	// Card.VariationOf), which can also significantly increase result counts.
+	IncludeVariations bool
+
+	// Lang restricts results to printings in this language ("ja", "de", "zhs", ...)
+	// by adding a lang: filter to the query. Empty leaves Scryfall's English default.
+	// Codes not in Languages are rejected before any request is made.
+	Lang string
+
+	// IncludeMultilingual returns printings in every language instead of
+	// just one per card, which multiplies result counts for popular cards.
+	IncludeMultilingual bool
+
+	// ExcludeContentWarnings drops cards Scryfall flags with content_warning from the
+	// results. Search syntax has no filter for the flag, so it's applied to each page;
+	// a search whose every card is dropped returns ErrNoCardsFound.
+	ExcludeContentWarnings bool
+
+	// ExcludeDigital adds -is:digital to the query, leaving out printings released only
+	// on Arena or MTGO. Paper printings that are also available digitally are kept; to
+	// restrict by where a printing can be played, search game:paper or filter with
+	// InGame instead.
+	ExcludeDigital bool
+}
+
+// searchURI builds the full /cards/search URI for query with these options
+func (o SearchOptions) searchURI(baseURL, query string) string {
+	params := url.Values{}
+	if o.Lang != "" {
+		// parenthesize so the filter applies to every branch of a query using "or"
+		query = "(" + query + ") lang:" + o.Lang
+	}
+	if o.ExcludeDigital {
+		query = "(" + query + ") -is:digital"
+	}
+	params.Set("q", query)
+	if o.IncludeExtras {
+		params.Set("include_extras", "true")
+	}
+	if o.IncludeVariations {
+		params.Set("include_variations", "true")
+	}
+	if o.IncludeMultilingual {
+		params.Set("include_multilingual", "true")
+	}
+	return baseURL + "/cards/search?" + params.Encode()
+}
+
+// SearchCardsWithOptions is like SearchCardsWithWarnings with extra search parameters
+func (c *Client) SearchCardsWithOptions(query string, options SearchOptions) ([]Card, []string, error) {
+	return c.SearchCardsWithOptionsContext(context.Background(), query, options)
+}
+
+// SearchCardsWithOptionsContext is SearchCardsWithOptions stopping when ctx is done,
+// returning the cards from the pages read before then along with ctx's error
+func (c *Client) SearchCardsWithOptionsContext(ctx context.Context, query string, options SearchOptions) ([]Card, []string, error) {
+	if options.Lang != "" && !IsValidLanguage(options.Lang) {
+		return nil, nil, fmt.Errorf("unsupported language code %q", options.Lang)
+	}
+
+	cards, warnings, err := c.followList(ctx, options.searchURI(c.baseURL, query), c.maxPages)
+	if isNotFound(err) {
+		return nil, nil, ErrNoCardsFound
+	}
+	if options.ExcludeContentWarnings {
+		cards = FilterCards(cards, NoContentWarning())
+		if len(cards) == 0 && err == nil {
+			return nil, nil, ErrNoCardsFound
+		}
+	}
+	return cards, warnings, err
+}
+
+// SearchCardsPage returns a single page of results for query, starting from page 1.
+// The List's TotalCards and HasMore tell how many pages there are, so a UI can jump
+// straight to any of them. A query that matches nothing returns ErrNoCardsFound.
+func (c *Client) SearchCardsPage(query string, page int) (*List, error) {
+	if page < 1 {
+		return nil, fmt.Errorf("invalid page %d, pages start at 1", page)
+	}
+
+	pageURI := SearchOptions{}.searchURI(c.baseURL, query) + "&page=" + strconv.Itoa(page)
+	list, err := c.getList(context.Background(), pageURI)
+	if isNotFound(err) {
+		return nil, ErrNoCardsFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ValidateQuery checks query by fetching only the first page of its results and returns
+// the warnings Scryfall attached, such as an ignored filter; none means the query is
+// fine as written. A query that matches nothing is still valid, and its warnings come
+// from the error object. A query Scryfall can't parse at all returns its warnings too,
+// along with its *APIError.
+func (c *Client) ValidateQuery(query string) ([]string, error) {
+	list, err := c.getList(context.Background(), SearchOptions{}.searchURI(c.baseURL, query))
+	if err != nil {
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			return nil, err
+		}
+		if apiErr.Code == "not_found" {
+			return apiErr.Warnings, nil
+		}
+		return apiErr.Warnings, err
+	}
+	return list.Warnings, nil
+}
+
+// SearchPager walks the results of a search one page at a time, see NewSearchPager.
+// Requests go through the client, so they share its rate limiting.
+type SearchPager struct {
+	client     *Client
+	nextURI    string // empty once the last page has been read
+	totalCards int
+	warnings   []string
+}
+
+// NewSearchPager returns a SearchPager for query. No request is made until Next.
+//
+//	pager := client.NewSearchPager("t:goblin")
+//	for pager.HasNext() {
+//		cards, err := pager.Next()
+//		...
+//	}
+func (c *Client) NewSearchPager(query string) *SearchPager {
+	return &SearchPager{
+		client:  c,
+		nextURI: SearchOptions{}.searchURI(c.baseURL, query),
+	}
+}
+
+// HasNext reports whether there is another page to read
+func (p *SearchPager) HasNext() bool {
+	return p.nextURI != ""
+}
+
+// Next fetches the next page of cards. A query that matches nothing returns
+// ErrNoCardsFound, and calling Next after the last page returns io.EOF.
+// A failed page can be retried by calling Next again.
+func (p *SearchPager) Next() ([]Card, error) {
+	return p.NextContext(context.Background())
+}
+
+// NextContext is Next with a ctx that cancels the page request
+func (p *SearchPager) NextContext(ctx context.Context) ([]Card, error) {
+	if !p.HasNext() {
+		return nil, io.EOF
+	}
+
+	list, err := p.client.getList(ctx, p.nextURI)
+	if isNotFound(err) {
+		p.nextURI = ""
+		return nil, ErrNoCardsFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p.totalCards = list.TotalCards
+	p.warnings = append(p.warnings, list.Warnings...)
+	p.nextURI = ""
+	if list.HasMore && list.NextPage != nil {
+		p.nextURI = list.NextPage.String()
+	}
+	return list.Data, nil
+}
+
+// TotalCards returns the total number of matching cards, known after the first Next
+func (p *SearchPager) TotalCards() int {
+	return p.totalCards
+}
+
+// Warnings returns every warning Scryfall attached to the pages read so far
+func (p *SearchPager) Warnings() []string {
+	return p.warnings
+}
+
+// IsValidLanguage reports whether code is one of the Languages Scryfall supports
+func IsValidLanguage(code string) bool {
+	for _, lang := range Languages {
+		if string(lang) == code {
+			return true
+		}
+	}
+	return false
+}
+
+// isNotFound reports whether err is Scryfall's "not_found" error
+func isNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code == "not_found"
+}
+
+// SearchCardsInLang searches for printings of matching cards in language lang
+func (c *Client) SearchCardsInLang(query, lang string) ([]Card, error) {
+	cards, _, err := c.SearchCardsWithOptions(query, SearchOptions{Lang: lang})
+	return cards, err
+}
+
+// SearchCardsFunc pages through the results of query and calls fn on each card as it
+// is decoded, so only one card is held in memory at a time. Returning ErrStopSearch
+// from fn stops the search without error; any other error stops it and is returned.
+// Reaching ClientOptions.MaxPages returns ErrMaxPagesReached.
+func (c *Client) SearchCardsFunc(query string, fn func(Card) error) error {
+	return c.SearchCardsFuncContext(context.Background(), query, fn)
+}
+
+// SearchCardsFuncContext is SearchCardsFunc stopping with ctx's error when ctx is done
+func (c *Client) SearchCardsFuncContext(ctx context.Context, query string, fn func(Card) error) error {
+	listURI := SearchOptions{}.searchURI(c.baseURL, query)
+
+	for page := 1; listURI != ""; page++ {
+		if c.maxPages > 0 && page > c.maxPages {
+			return ErrMaxPagesReached
+		}
+		endpoint, err := c.apiEndpoint(listURI)
+		if err != nil {
+			return err
+		}
+
+		var nextPage string
+		err = c.doRequestFunc(ctx, "GET", endpoint, nil, func(r io.Reader) error {
+			var decodeErr error
+			nextPage, decodeErr = streamListPage(json.NewDecoder(r), fn)
+			return decodeErr
+		})
+		if errors.Is(err, ErrStopSearch) {
+			return nil
+		}
+		if isNotFound(err) {
+			return ErrNoCardsFound
+		}
+		if err != nil {
+			return err
+		}
+		listURI = nextPage
+	}
+	return nil
+}
+
+// streamListPage decodes one List page, calling fn on each card in data as it is read.
+// It returns the next_page URI, or "" on the last page.
+func streamListPage(dec *json.Decoder, fn func(Card) error) (string, error) {
+	if err := expectDelim(dec, '{'); err != nil {
+		return "", err
+	}
+
+	var nextPage string
+	hasMore := false
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return "", err
+		}
+		key, _ := token.(string)
+
+		switch key {
+		case "data":
+			if err := expectDelim(dec, '['); err != nil {
+				return "", err
+			}
+			for dec.More() {
+				var card Card
+				if err := dec.Decode(&card); err != nil {
+					return "", err
+				}
+				if err := fn(card); err != nil {
+					return "", err
+				}
+			}
+			if err := expectDelim(dec, ']'); err != nil {
+				return "", err
+			}
+		case "has_more":
+			if err := dec.Decode(&hasMore); err != nil {
+				return "", err
+			}
+		case "next_page":
+			if err := dec.Decode(&nextPage); err != nil {
+				return "", err
+			}
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if !hasMore {
+		return "", nil
+	}
+	return nextPage, nil
+}
+
+// expectDelim reads the next token and checks that it is delim
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %v in list response, got %v", delim, token)
+	}
+	return nil
+}
+
+// SearchCardsText returns the first page of results for query in Scryfall's
+// plain-text format, a human-readable block per card
+func (c *Client) SearchCardsText(query string) (string, error) {
+	return c.searchCardsRaw(query, "text")
+}
+
+// SearchCardsCSV returns the first page of results for query as CSV, ready for a spreadsheet
+func (c *Client) SearchCardsCSV(query string) (string, error) {
+	return c.searchCardsRaw(query, "csv")
+}
+
+// searchCardsRaw runs a search with format set and returns the response body as is
+func (c *Client) searchCardsRaw(query, format string) (string, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("format", format)
+
+	var body []byte
+	err := c.doRequestFunc(context.Background(), "GET", "/cards/search?"+params.Encode(), nil, func(r io.Reader) error {
+		var readErr error
+		body, readErr = io.ReadAll(r)
+		return readErr
+	})
+	if isNotFound(err) {
+		return "", ErrNoCardsFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// CardsLegalIn returns every card legal in format, optionally narrowed by extraQuery
+// (any Scryfall search syntax). Filtering happens on Scryfall's side; see InFormat
+// for filtering cards that are already fetched.
+func (c *Client) CardsLegalIn(format Format, extraQuery string) ([]Card, error) {
+	query := "f:" + string(format)
+	if strings.TrimSpace(extraQuery) != "" {
+		query += " (" + extraQuery + ")"
+	}
+	cards, _, err := c.searchCards(context.Background(), query)
+	return cards, err
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// pagedSearch answers /cards/search with pages of cards named in pages, linking each
+// page to the next through next_page. A query of "nothing" answers Scryfall's 404.
+// Pages in failOnce answer 500 the first time they are requested.
+type pagedSearch struct {
+	pages    [][]string
+	warnings []string
+	failOnce map[int]bool
+	requests int
+}
+
+func (s *pagedSearch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests++
+	if r.URL.Path != "/cards/search" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.URL.Query().Get("q") == "nothing" {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"object":"error","code":"not_found","details":"Your query didn't match any cards."}`))
+		return
+	}
+
+	page := 1
+	if p := r.URL.Query().Get("page"); p != "" {
+		page, _ = strconv.Atoi(p)
+	}
+	if s.failOnce[page] {
+		delete(s.failOnce, page)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"object":"error","code":"internal_error","details":"try again"}`))
+		return
+	}
+
+	total := 0
+	for _, names := range s.pages {
+		total += len(names)
+	}
+	fmt.Fprintf(w, `{"object":"list","total_cards":%d,"has_more":%t`, total, page < len(s.pages))
+	if page < len(s.pages) {
+		query := r.URL.Query()
+		query.Set("page", strconv.Itoa(page+1))
+		fmt.Fprintf(w, `,"next_page":"http://%s/cards/search?%s"`, r.Host, query.Encode())
+	}
+	if len(s.warnings) > 0 && page == 1 {
+		fmt.Fprintf(w, `,"warnings":[%q]`, s.warnings[0])
+	}
+	w.Write([]byte(`,"data":[`))
+	for i, name := range s.pages[page-1] {
+		if i > 0 {
+			w.Write([]byte(","))
+		}
+		fmt.Fprintf(w, `{"object":"card","name":%q}`, name)
+	}
+	w.Write([]byte("]}"))
+}
+
+func ExampleClient_NewSearchPager() {
+	server := httptest.NewServer(&pagedSearch{pages: [][]string{
+		{"Goblin Guide", "Goblin Lackey"},
+		{"Goblin Matron"},
+	}})
+	defer server.Close()
+
+	options := DefaultClientOptions
+	options.APIURL = server.URL
+	options.UserAgent = "ExampleApp/1.0"
+	options.DisableDB = true
+	client, err := NewClientWithOptions(options)
+	if err != nil {
+		panic(err)
+	}
+
+	pager := client.NewSearchPager("t:goblin")
+	for pager.HasNext() {
+		cards, err := pager.Next()
+		if err != nil {
+			panic(err)
+		}
+		for _, card := range cards {
+			fmt.Println(card.Name)
+		}
+	}
+	fmt.Println(pager.TotalCards(), "cards")
+	// Output:
+	// Goblin Guide
+	// Goblin Lackey
+	// Goblin Matron
+	// 3 cards
+}
+
+func TestSearchPager(t *testing.T) {
+	search := &pagedSearch{
+		pages:    [][]string{{"a", "b"}, {"c", "d"}, {"e"}},
+		warnings: []string{`Invalid expression "zz:1" was ignored.`},
+		failOnce: map[int]bool{2: true},
+	}
+	client := newTestClient(t, search)
+	pager := client.NewSearchPager("t:goblin zz:1")
+
+	if search.requests != 0 {
+		t.Fatalf("NewSearchPager made %d requests, want none before Next", search.requests)
+	}
+
+	cards, err := pager.Next()
+	if err != nil || len(cards) != 2 || cards[0].Name != "a" {
+		t.Fatalf("page 1 = %v, %v", cards, err)
+	}
+	if pager.TotalCards() != 5 {
+		t.Errorf("TotalCards = %d, want 5", pager.TotalCards())
+	}
+	if len(pager.Warnings()) != 1 || pager.Warnings()[0] != search.warnings[0] {
+		t.Errorf("Warnings = %v, want %v", pager.Warnings(), search.warnings)
+	}
+
+	// the first try at page 2 fails; it stays the next page and can be retried
+	if _, err := pager.Next(); err == nil {
+		t.Fatal("expected page 2 to fail the first time")
+	}
+	if !pager.HasNext() {
+		t.Fatal("HasNext is false after a failed page")
+	}
+	cards, err = pager.Next()
+	if err != nil || len(cards) != 2 || cards[0].Name != "c" {
+		t.Fatalf("retried page 2 = %v, %v", cards, err)
+	}
+
+	cards, err = pager.Next()
+	if err != nil || len(cards) != 1 || cards[0].Name != "e" {
+		t.Fatalf("page 3 = %v, %v", cards, err)
+	}
+	if pager.HasNext() {
+		t.Error("HasNext is true after the last page")
+	}
+	if _, err := pager.Next(); err != io.EOF {
+		t.Errorf("Next after the last page = %v, want io.EOF", err)
+	}
+}
+
+func TestSearchPagerNoCards(t *testing.T) {
+	client := newTestClient(t, &pagedSearch{})
+	pager := client.NewSearchPager("nothing")
+
+	if _, err := pager.Next(); !errors.Is(err, ErrNoCardsFound) {
+		t.Fatalf("Next = %v, want ErrNoCardsFound", err)
+	}
+	if pager.HasNext() {
+		t.Error("HasNext is true after ErrNoCardsFound")
+	}
+	if _, err := pager.Next(); err != io.EOF {
+		t.Errorf("Next after ErrNoCardsFound = %v, want io.EOF", err)
+	}
+}
+
+func TestValidateQuery(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("q") {
+		case "t:goblin zz:1":
+			w.Write([]byte(`{"object":"list","total_cards":1,"has_more":false,
+				"warnings":["Invalid expression \"zz:1\" was ignored."],
+				"data":[{"object":"card","name":"Goblin Guide"}]}`))
+		case "t:nothing zz:1":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"object":"error","code":"not_found","status":404,
+				"warnings":["Invalid expression \"zz:1\" was ignored."],
+				"details":"Your query didn't match any cards."}`))
+		default:
+			// the error api.txt gives for q=is:slick cmc>cmc
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"object":"error","code":"bad_request","status":400,
+				"warnings":["Invalid expression \"is:slick\" was ignored. Checking if cards are \"slick\" is not supported","The sides of your comparison must be different."],
+				"details":"All of your terms were ignored."}`))
+		}
+	}))
+
+	for _, query := range []string{"t:goblin zz:1", "t:nothing zz:1"} {
+		warnings, err := client.ValidateQuery(query)
+		if err != nil || len(warnings) != 1 || warnings[0] != `Invalid expression "zz:1" was ignored.` {
+			t.Errorf("ValidateQuery(%q) = %q, %v; want the zz:1 warning", query, warnings, err)
+		}
+	}
+
+	warnings, err := client.ValidateQuery("is:slick cmc>cmc")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "bad_request" {
+		t.Fatalf("ValidateQuery(bad query) error = %v, want the bad_request *APIError", err)
+	}
+	if len(warnings) != 2 || !reflect.DeepEqual(warnings, apiErr.Warnings) {
+		t.Errorf("ValidateQuery(bad query) warnings = %q, want both of %q", warnings, apiErr.Warnings)
+	}
+}
+
+// Canceling ctx mid-walk stops the search, keeping the pages read before it
+func TestSearchCardsWithOptionsContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	search := &pagedSearch{pages: [][]string{{"a", "b"}, {"c"}, {"d"}}}
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			// cancel while the second page is in flight and hold it until the client gives up
+			cancel()
+			<-r.Context().Done()
+			return
+		}
+		search.ServeHTTP(w, r)
+	}))
+
+	cards, _, err := client.SearchCardsWithOptionsContext(ctx, "t:goblin", SearchOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if got := cardNames(cards); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("cards = %v, want the first page's [a b]", got)
+	}
+	if search.requests != 1 {
+		t.Errorf("served %d pages after the cancel, want only the first", search.requests)
+	}
+
+	// an already canceled ctx makes no requests at all
+	if err := client.SearchCardsFuncContext(ctx, "t:goblin", func(Card) error { return nil }); !errors.Is(err, context.Canceled) {
+		t.Errorf("SearchCardsFuncContext err = %v, want context.Canceled", err)
+	}
+	if _, err := client.CardsInSetContext(ctx, &Set{Code: "isd"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("CardsInSetContext err = %v, want context.Canceled", err)
+	}
+	if search.requests != 1 {
+		t.Errorf("requests made with a canceled ctx: %d", search.requests-1)
+	}
+}
+
+func TestSearchExcludeContentWarnings(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cards := `{"object":"card","name":"Flagged","content_warning":true}`
+		if r.URL.Query().Get("q") == "mixed" {
+			cards += `,{"object":"card","name":"Clean"}`
+		}
+		fmt.Fprintf(w, `{"object":"list","total_cards":2,"has_more":false,"data":[%s]}`, cards)
+	}))
+	options := SearchOptions{ExcludeContentWarnings: true}
+
+	cards, _, err := client.SearchCardsWithOptions("mixed", options)
+	if err != nil || !reflect.DeepEqual(cardNames(cards), []string{"Clean"}) {
+		t.Errorf("mixed = %v, %v; want [Clean]", cardNames(cards), err)
+	}
+	// every card filtered out is the same as no match
+	if _, _, err := client.SearchCardsWithOptions("flagged", options); !errors.Is(err, ErrNoCardsFound) {
+		t.Errorf("all flagged err = %v, want ErrNoCardsFound", err)
+	}
+}
@@ -0,0 +1,403 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CardsInSet returns every card in set by paginating its SearchURI.
+// If the set has no SearchURI (for example a partially filled Set) it is fetched by code first.
+func (c *Client) CardsInSet(set *Set) ([]Card, error) {
+	return c.CardsInSetContext(context.Background(), set)
+}
+
+// CardsInSetContext is CardsInSet stopping with ctx's error when ctx is done
+func (c *Client) CardsInSetContext(ctx context.Context, set *Set) ([]Card, error) {
+	if set.SearchURI.String() == "" {
+		fetched, err := c.getSet(ctx, set.Code)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error fetching set %s: %v", set.Code, err)
+		}
+		set = fetched
+	}
+	cards, _, err := c.followList(ctx, set.SearchURI.String(), c.maxPages)
+	return cards, err
+}
+
+// CardsInSetCode fetches the set with the given code and returns every card in it
+func (c *Client) CardsInSetCode(code string) ([]Card, error) {
+	set, err := c.getSet(context.Background(), code)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching set %s: %v", code, err)
+	}
+	return c.CardsInSet(set)
+}
+
+// CardSet returns the Set a card was printed in, following its SetURI or, when that's
+// empty, looking the set up by code. Sets are cached per client, so resolving many
+// cards from the same set costs one request.
+func (c *Client) CardSet(card *Card) (*Set, error) {
+	if set, ok := c.cachedSet(card.Set); ok {
+		return set, nil
+	}
+
+	var set *Set
+	if card.SetURI.String() != "" {
+		endpoint, err := c.apiEndpoint(card.SetURI.String())
+		if err != nil {
+			return nil, err
+		}
+		set = &Set{}
+		if err := c.makeRequest(endpoint, set); err != nil {
+			return nil, fmt.Errorf("error fetching set %s: %v", card.Set, err)
+		}
+	} else {
+		fetched, err := c.getSet(context.Background(), card.Set)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching set %s: %v", card.Set, err)
+		}
+		set = fetched
+	}
+
+	c.cacheSet(card.Set, set)
+	return set, nil
+}
+
+// maxSetFetches bounds how many GetSets requests are in flight at once. Requests still
+// start no faster than the rate limit allows; this only overlaps their round trips.
+const maxSetFetches = 4
+
+// SetErrors maps set codes to the error fetching each one, see GetSets
+type SetErrors map[string]error
+
+func (e SetErrors) Error() string {
+	codes := make([]string, 0, len(e))
+	for code := range e {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	messages := make([]string, len(codes))
+	for i, code := range codes {
+		messages[i] = fmt.Sprintf("%s: %v", code, e[code])
+	}
+	return fmt.Sprintf("error fetching %d sets: %s", len(e), strings.Join(messages, "; "))
+}
+
+// GetSets fetches the sets with the given codes, several at a time, keyed by lowercase
+// code. Sets are shared with CardSet's per-client cache, so codes already fetched cost
+// no request. When some codes fail, the sets that were fetched are returned along with
+// a SetErrors holding the error for each failed code.
+func (c *Client) GetSets(codes []string) (map[string]*Set, error) {
+	sets := make(map[string]*Set, len(codes))
+	failed := make(SetErrors)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	limit := make(chan struct{}, maxSetFetches)
+
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		code = strings.ToLower(code)
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		if set, ok := c.cachedSet(code); ok {
+			sets[code] = set
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+
+			set, err := c.getSet(context.Background(), code)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed[code] = err
+				return
+			}
+			c.cacheSet(code, set)
+			sets[code] = set
+		}()
+	}
+	wg.Wait()
+
+	if len(failed) > 0 {
+		return sets, failed
+	}
+	return sets, nil
+}
+
+// cachedSet returns the set with code from the per-client set cache
+func (c *Client) cachedSet(code string) (*Set, bool) {
+	c.setCacheMu.Lock()
+	defer c.setCacheMu.Unlock()
+	set, ok := c.setCache[strings.ToLower(code)]
+	return set, ok
+}
+
+// cacheSet stores set in the per-client set cache under code
+func (c *Client) cacheSet(code string, set *Set) {
+	c.setCacheMu.Lock()
+	defer c.setCacheMu.Unlock()
+	if c.setCache == nil {
+		c.setCache = make(map[string]*Set)
+	}
+	c.setCache[strings.ToLower(code)] = set
+}
+
+// DownloadSetIcon writes the set's SVG icon to w.
+// If the set has no IconSVGURI it is fetched by code first.
+func (c *Client) DownloadSetIcon(set *Set, w io.Writer) error {
+	if set.IconSVGURI.String() == "" {
+		fetched, err := c.getSet(context.Background(), set.Code)
+		if err != nil {
+			return fmt.Errorf("error fetching set %s: %v", set.Code, err)
+		}
+		set = fetched
+	}
+	if err := c.download(set.IconSVGURI.String(), "image/svg+xml", w); err != nil {
+		return fmt.Errorf("error downloading icon for set %s: %v", set.Code, err)
+	}
+	return nil
+}
+
+// DownloadSetIconByCode fetches the set with the given code and writes its SVG icon to w
+func (c *Client) DownloadSetIconByCode(code string, w io.Writer) error {
+	set, err := c.getSet(context.Background(), code)
+	if err != nil {
+		return fmt.Errorf("error fetching set %s: %v", code, err)
+	}
+	return c.DownloadSetIcon(set, w)
+}
+
+// ReleaseDate parses ReleasedAt, returning false when the set has no (or an unparseable) release date
+func (s *Set) ReleaseDate() (time.Time, bool) {
+	if s.ReleasedAt == nil || *s.ReleasedAt == "" {
+		return time.Time{}, false
+	}
+	released, err := time.Parse(releaseDateLayout, *s.ReleasedAt)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return released, true
+}
+
+// setList is the List wrapper returned by /sets
+type setList struct {
+	Object  string `json:"object"`
+	HasMore bool   `json:"has_more"`
+	Data    []Set  `json:"data"`
+}
+
+// ListSets returns every set on Scryfall
+func (c *Client) ListSets() ([]Set, error) {
+	var list setList
+	if err := c.makeRequest("/sets", &list); err != nil {
+		return nil, fmt.Errorf("error fetching sets: %v", err)
+	}
+	return list.Data, nil
+}
+
+// ListSetsByType returns every set of the given type, e.g. Expansion or Commander
+func (c *Client) ListSetsByType(t SetType) ([]Set, error) {
+	sets, err := c.ListSets()
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []Set
+	for _, set := range sets {
+		if set.SetType == t {
+			filtered = append(filtered, set)
+		}
+	}
+	return filtered, nil
+}
+
+// LatestSet returns the most recently released set of the given type.
+// Sets with a release date in the future (announced but unreleased) are skipped.
+func (c *Client) LatestSet(t SetType) (*Set, error) {
+	sets, err := c.ListSetsByType(t)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var latest *Set
+	var latestDate time.Time
+	for i := range sets {
+		released, ok := sets[i].ReleaseDate()
+		if !ok || released.After(now) {
+			continue
+		}
+		if latest == nil || released.After(latestDate) {
+			latest = &sets[i]
+			latestDate = released
+		}
+	}
+
+	if latest == nil {
+		return nil, fmt.Errorf("no released sets of type %s", t)
+	}
+	return latest, nil
+}
+
+// SetCompletion compares the cards in the set with code setCode against the printings
+// stored locally, matching by collector number. It returns how many of the set's
+// cards are stored, how many the set has, and the cards that are missing.
+func (c *Client) SetCompletion(setCode string) (owned, total int, missing []Card, err error) {
+	setCode = strings.ToLower(setCode)
+
+	queries, err := c.queries()
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	cards, err := c.CardsInSetCode(setCode)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	rows, err := queries.GetPrintingsBySet(context.Background(), setCode)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("error loading printings for set %s: %v", setCode, err)
+	}
+	stored := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		stored[row.CollectorNumber] = true
+	}
+
+	for _, card := range cards {
+		if stored[card.CollectorNumber] {
+			owned++
+		} else {
+			missing = append(missing, card)
+		}
+	}
+	return owned, len(cards), missing, nil
+}
+
+// MissingCollectorNumbers returns the collector numbers from 1 to the set's printed size
+// (its card count when Scryfall has no printed size) that have no printing stored
+// locally, in order. Stored numbers with a suffix, like "12a" or "12★", count as
+// owning 12; numbers without leading digits and numbers past the printed size, such
+// as extra art treatments, are ignored.
+func (c *Client) MissingCollectorNumbers(setCode string) ([]string, error) {
+	setCode = strings.ToLower(setCode)
+
+	queries, err := c.queries()
+	if err != nil {
+		return nil, err
+	}
+
+	set, err := c.getSet(context.Background(), setCode)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching set %s: %v", setCode, err)
+	}
+	size := set.CardCount
+	if set.PrintedSize != nil {
+		size = *set.PrintedSize
+	}
+
+	rows, err := queries.GetPrintingsBySet(context.Background(), setCode)
+	if err != nil {
+		return nil, fmt.Errorf("error loading printings for set %s: %v", setCode, err)
+	}
+	owned := make(map[int]bool, len(rows))
+	for _, row := range rows {
+		if number, _ := splitCollectorNumber(row.CollectorNumber); number != math.MaxInt {
+			owned[number] = true
+		}
+	}
+
+	var missing []string
+	for number := 1; number <= size; number++ {
+		if !owned[number] {
+			missing = append(missing, strconv.Itoa(number))
+		}
+	}
+	return missing, nil
+}
+
+// CurrentStandardSets returns the sets currently in Standard, oldest first.
+// Scryfall has no direct query for this. The candidates are the expansion and core
+// sets that first printed a Standard-legal card. Old sets also show up here through
+// cards that were reprinted later. Standard is the newest candidates up to the first
+// one, going back in time, that has a card neither legal nor banned in Standard.
+//
+// The first call makes several requests, about 20-30 at the usual rate limit:
+//   - one unique:cards search, one page per 175 Standard-legal cards (roughly 20)
+//   - one /sets request
+//   - one single-page search per Standard set, plus one for the newest rotated set
+//
+// Concurrent callers wait for it. The result, empty or not, is cached for the life
+// of the client.
+func (c *Client) CurrentStandardSets() ([]Set, error) {
+	c.standardMu.Lock()
+	defer c.standardMu.Unlock()
+	if c.standardComputed {
+		return c.standardSets, nil
+	}
+
+	cards, _, err := c.SearchCardsWithOptions("f:standard -is:reprint (st:expansion or st:core) unique:cards", SearchOptions{})
+	if err != nil && !errors.Is(err, ErrNoCardsFound) {
+		return nil, fmt.Errorf("error searching standard cards: %v", err)
+	}
+	candidateCodes := make(map[string]bool)
+	for _, card := range cards {
+		candidateCodes[card.Set] = true
+	}
+
+	sets, err := c.ListSets()
+	if err != nil {
+		return nil, err
+	}
+	var candidates []Set
+	for _, set := range sets {
+		if candidateCodes[set.Code] && (set.SetType == Expansion || set.SetType == Core) {
+			candidates = append(candidates, set)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, _ := candidates[i].ReleaseDate()
+		b, _ := candidates[j].ReleaseDate()
+		return a.Before(b)
+	})
+
+	// walk back from the newest set; the first set with a card that isn't in Standard
+	// (rotated, not just banned) is older than every set in Standard
+	oldest := len(candidates)
+	for oldest > 0 {
+		_, err := c.SearchCardsPage("e:"+candidates[oldest-1].Code+" -f:standard -banned:standard", 1)
+		if errors.Is(err, ErrNoCardsFound) {
+			oldest--
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error checking set %s: %v", candidates[oldest-1].Code, err)
+		}
+		break
+	}
+	standard := candidates[oldest:]
+
+	c.standardSets = standard
+	c.standardComputed = true
+	return standard, nil
+}
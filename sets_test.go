@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// standardServer answers /sets with sets, the Standard candidates search with a card
+// from each of candidates, and each set's not-in-Standard search with a card only for
+// the sets in rotated
+type standardServer struct {
+	sets       []Set
+	candidates []string
+	rotated    map[string]bool
+	requests   []string
+}
+
+func (s *standardServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	s.requests = append(s.requests, r.URL.Path+" "+query)
+
+	var cards []Card
+	switch {
+	case r.URL.Path == "/sets":
+		json.NewEncoder(w).Encode(setList{Object: "list", Data: s.sets})
+		return
+	case r.URL.Path != "/cards/search":
+		http.NotFound(w, r)
+		return
+	case strings.HasPrefix(query, "f:standard "):
+		for _, code := range s.candidates {
+			cards = append(cards, Card{Object: "card", Name: "First in " + code, Set: code})
+		}
+	case strings.HasPrefix(query, "e:"):
+		code, _, _ := strings.Cut(strings.TrimPrefix(query, "e:"), " ")
+		if s.rotated[code] {
+			cards = append(cards, Card{Object: "card", Name: "Rotated from " + code, Set: code})
+		}
+	}
+	if len(cards) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"object":"error","code":"not_found","details":"Your query didn't match any cards."}`))
+		return
+	}
+	json.NewEncoder(w).Encode(List{Object: "list", TotalCards: len(cards), Data: cards})
+}
+
+func testSet(code string, setType SetType, releasedAt string) Set {
+	return Set{Object: "set", Code: code, Name: strings.ToUpper(code), SetType: setType, ReleasedAt: &releasedAt}
+}
+
+func TestCurrentStandardSets(t *testing.T) {
+	server := &standardServer{
+		// newest first, as Scryfall lists them
+		sets: []Set{
+			testSet("tdm", Expansion, "2099-04-11"), // announced, nothing legal yet
+			testSet("fdn", Core, "2024-11-15"),
+			testSet("dsk", Expansion, "2024-09-27"),
+			testSet("blb", Expansion, "2024-08-02"),
+			testSet("cmm", Masters, "2023-08-04"),
+			testSet("dmu", Expansion, "2022-09-09"),
+			testSet("sth", Expansion, "1998-03-02"),
+		},
+		// sth and dmu first printed cards that were reprinted into Standard later
+		candidates: []string{"sth", "dmu", "blb", "dsk", "fdn", "cmm"},
+		rotated:    map[string]bool{"sth": true, "dmu": true},
+	}
+	client := newTestClient(t, server)
+
+	sets, err := client.CurrentStandardSets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var codes []string
+	for _, set := range sets {
+		codes = append(codes, set.Code)
+	}
+	if want := []string{"blb", "dsk", "fdn"}; !reflect.DeepEqual(codes, want) {
+		t.Errorf("CurrentStandardSets = %v, want %v", codes, want)
+	}
+	if !strings.Contains(server.requests[0], "unique:cards") {
+		t.Errorf("candidate search %q, want unique:cards to keep it to one result per card", server.requests[0])
+	}
+	for _, request := range server.requests {
+		if strings.Contains(request, "e:sth") {
+			t.Errorf("checked %q after the walk reached a rotated set", request)
+		}
+	}
+
+	requests := len(server.requests)
+	if _, err := client.CurrentStandardSets(); err != nil {
+		t.Fatal(err)
+	}
+	if len(server.requests) != requests {
+		t.Errorf("second call made %d more requests, want the cached result", len(server.requests)-requests)
+	}
+}
+
+func TestCurrentStandardSetsEmpty(t *testing.T) {
+	server := &standardServer{sets: []Set{testSet("sth", Expansion, "1998-03-02")}}
+	client := newTestClient(t, server)
+
+	for i := range 2 {
+		sets, err := client.CurrentStandardSets()
+		if err != nil || len(sets) != 0 {
+			t.Fatalf("call %d = %v, %v; want no sets", i+1, sets, err)
+		}
+	}
+	if len(server.requests) != 2 {
+		t.Errorf("requests %v, want one search and one /sets, cached after", server.requests)
+	}
+}
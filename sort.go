@@ -0,0 +1,94 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// SortKey selects the field SortCards orders by
+type SortKey string
+
+const (
+	SortByUSD      SortKey = "usd"
+	SortByUSDFoil  SortKey = "usd_foil"
+	SortByEUR      SortKey = "eur"
+	SortByTix      SortKey = "tix"
+	SortByEDHREC   SortKey = "edhrec" // PopularityRank
+	SortByPenny    SortKey = "penny"  // PennyDreadfulRank
+	SortByCMC      SortKey = "cmc"
+	SortByReleased SortKey = "released"
+	SortByName     SortKey = "name"
+)
+
+// SortCards sorts cards in place by the given key, ascending unless desc is set.
+// Cards missing a value for the key (no price, no EDHREC or Penny Dreadful rank,
+// unparseable date) always sort to the end, in their original relative order.
+// Unknown keys leave the slice unchanged.
+func SortCards(cards []Card, by SortKey, desc bool) {
+	if by == SortByName {
+		sort.SliceStable(cards, func(i, j int) bool {
+			a, b := strings.ToLower(cards[i].Name), strings.ToLower(cards[j].Name)
+			if desc {
+				return a > b
+			}
+			return a < b
+		})
+		return
+	}
+
+	value := sortValue(by)
+	if value == nil {
+		return
+	}
+
+	sort.SliceStable(cards, func(i, j int) bool {
+		a, aok := value(&cards[i])
+		b, bok := value(&cards[j])
+		if !aok || !bok {
+			return aok && !bok
+		}
+		if desc {
+			return a > b
+		}
+		return a < b
+	})
+}
+
+// sortValue returns the numeric value SortCards compares for key
+func sortValue(key SortKey) func(*Card) (float64, bool) {
+	switch key {
+	case SortByUSD, SortByUSDFoil, SortByEUR, SortByTix:
+		return func(c *Card) (float64, bool) {
+			if p := c.price(string(key)); p != nil {
+				return *p, true
+			}
+			return 0, false
+		}
+	case SortByEDHREC:
+		return rankValue((*Card).PopularityRank)
+	case SortByPenny:
+		return rankValue((*Card).PennyDreadfulRank)
+	case SortByCMC:
+		return func(c *Card) (float64, bool) {
+			return c.CMC, true
+		}
+	case SortByReleased:
+		return func(c *Card) (float64, bool) {
+			released, err := c.ReleaseDate()
+			if err != nil {
+				return 0, false
+			}
+			return float64(released.Unix()), true
+		}
+	default:
+		return nil
+	}
+}
+
+// rankValue adapts a rank accessor like PopularityRank to a sortValue function
+func rankValue(rank func(*Card) (int, bool)) func(*Card) (float64, bool) {
+	return func(c *Card) (float64, bool) {
+		value, ok := rank(c)
+		return float64(value), ok
+	}
+}
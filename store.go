@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/ninesl/scryfall-api/scryfall"
+)
+
+// requiredPrintingColumns are always stored, whatever StoreFields says
+var requiredPrintingColumns = []string{"id", "oracle_id"}
+
+// printingColumnFields maps each printings column to its UpsertPrintingParams field index
+var printingColumnFields = func() map[string]int {
+	columns := make(map[string]int)
+	t := reflect.TypeOf(scryfall.UpsertPrintingParams{})
+	for i := 0; i < t.NumField(); i++ {
+		columns[snakeCase(t.Field(i).Name)] = i
+	}
+	return columns
+}()
+
+// snakeCase turns a sqlc field name like "TcgplayerEtchedID" back into its column name
+func snakeCase(name string) string {
+	name = strings.ReplaceAll(name, "ID", "Id")
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// storeFieldSet validates a StoreFields allowlist and returns it as a set, or nil to store everything
+func storeFieldSet(fields []string) (map[string]bool, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	set := make(map[string]bool, len(fields)+len(requiredPrintingColumns))
+	for _, field := range fields {
+		if _, ok := printingColumnFields[field]; !ok {
+			return nil, fmt.Errorf("unknown printings column %q in StoreFields", field)
+		}
+		set[field] = true
+	}
+	for _, column := range requiredPrintingColumns {
+		set[column] = true
+	}
+	return set, nil
+}
+
+// trimPrintingParams clears every column not in the client's StoreFields, so it is
+// stored as NULL or its empty value
+func (c *Client) trimPrintingParams(params scryfall.UpsertPrintingParams) scryfall.UpsertPrintingParams {
+	if c.storeFields == nil {
+		return params
+	}
+
+	v := reflect.ValueOf(&params).Elem()
+	for column, i := range printingColumnFields {
+		if !c.storeFields[column] {
+			field := v.Field(i)
+			field.Set(reflect.Zero(field.Type()))
+		}
+	}
+	return params
+}
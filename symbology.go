@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// symbolList is the List wrapper returned by /symbology
+type symbolList struct {
+	Object  string       `json:"object"`
+	HasMore bool         `json:"has_more"`
+	Data    []CardSymbol `json:"data"`
+}
+
+// GetSymbology returns every card symbol Scryfall knows about, including its SVG URI and mana value
+func (c *Client) GetSymbology() ([]CardSymbol, error) {
+	var list symbolList
+	if err := c.makeRequest("/symbology", &list); err != nil {
+		return nil, fmt.Errorf("error fetching symbology: %v", err)
+	}
+	return list.Data, nil
+}
+
+// ParseManaCost asks Scryfall to normalize cost (e.g. "2WW" becomes "{2}{W}{W}") and compute its mana value
+func (c *Client) ParseManaCost(cost string) (ManaCostResult, error) {
+	var result ManaCostResult
+	if err := c.makeRequest("/symbology/parse-mana?cost="+url.QueryEscape(cost), &result); err != nil {
+		return ManaCostResult{}, fmt.Errorf("error parsing mana cost %q: %v", cost, err)
+	}
+	return result, nil
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/url"
+	"time"
 )
 
 // A List object represents a requested sequence of other objects (Cards, Sets, etc).
@@ -37,6 +39,10 @@ type List struct {
 	// the warnings and re-submit your request.
 	//NULLABLE
 	Warnings []string `json:"warnings"`
+
+	//The identifiers from a /cards/collection request that could not be matched to a card
+	//NULLABLE
+	NotFound []CardIdentifier `json:"not_found"`
 }
 type SetType string
 
@@ -66,6 +72,149 @@ const (
 	Minigame        SetType = "minigame"         // A set that contains minigame card inserts from booster packs
 )
 
+// Format is a play format key used in Card.Legalities.
+// Constants are prefixed because several format names (Commander, Alchemy) are also SetTypes.
+type Format string
+
+const (
+	FormatStandard        Format = "standard"
+	FormatFuture          Format = "future"
+	FormatHistoric        Format = "historic"
+	FormatTimeless        Format = "timeless"
+	FormatGladiator       Format = "gladiator"
+	FormatPioneer         Format = "pioneer"
+	FormatModern          Format = "modern"
+	FormatLegacy          Format = "legacy"
+	FormatPauper          Format = "pauper"
+	FormatVintage         Format = "vintage"
+	FormatPenny           Format = "penny"
+	FormatCommander       Format = "commander"
+	FormatOathbreaker     Format = "oathbreaker"
+	FormatStandardBrawl   Format = "standardbrawl"
+	FormatBrawl           Format = "brawl"
+	FormatAlchemy         Format = "alchemy"
+	FormatPauperCommander Format = "paupercommander"
+	FormatDuel            Format = "duel"
+	FormatOldSchool       Format = "oldschool"
+	FormatPremodern       Format = "premodern"
+	FormatPredh           Format = "predh"
+)
+
+// Layout categorizes the arrangement of card parts and faces.
+// Constants are prefixed because several layout names (Token, Vanguard) are also SetTypes.
+type Layout string
+
+const (
+	LayoutNormal           Layout = "normal"             // A standard Magic card with one face
+	LayoutSplit            Layout = "split"              // A split-faced card
+	LayoutFlip             Layout = "flip"               // Cards that invert vertically with the flip keyword
+	LayoutTransform        Layout = "transform"          // Double-sided cards that transform
+	LayoutModalDFC         Layout = "modal_dfc"          // Double-sided cards that can be played either-side
+	LayoutMeld             Layout = "meld"               // Cards with meld parts printed on the back
+	LayoutLeveler          Layout = "leveler"            // Cards with Level Up
+	LayoutClass            Layout = "class"              // Class-type enchantment cards
+	LayoutCase             Layout = "case"               // Case-type enchantment cards
+	LayoutSaga             Layout = "saga"               // Saga-type cards
+	LayoutAdventure        Layout = "adventure"          // Cards with an Adventure spell part
+	LayoutMutate           Layout = "mutate"             // Cards with Mutate
+	LayoutPrototype        Layout = "prototype"          // Cards with Prototype
+	LayoutBattle           Layout = "battle"             // Battle-type cards
+	LayoutPlanar           Layout = "planar"             // Plane and Phenomenon-type cards
+	LayoutScheme           Layout = "scheme"             // Scheme-type cards
+	LayoutVanguard         Layout = "vanguard"           // Vanguard-type cards
+	LayoutToken            Layout = "token"              // Token cards
+	LayoutDoubleFacedToken Layout = "double_faced_token" // Tokens with another token printed on the back
+	LayoutEmblem           Layout = "emblem"             // Emblem cards
+	LayoutAugment          Layout = "augment"            // Cards with Augment
+	LayoutHost             Layout = "host"               // Host-type cards
+	LayoutArtSeries        Layout = "art_series"         // Art Series collectable double-faced cards
+	LayoutReversibleCard   Layout = "reversible_card"    // A Magic card with two sides that are unrelated
+)
+
+// Finish is a way a printing can be made, as listed in Card.Finishes
+type Finish string
+
+const (
+	FinishNonfoil Finish = "nonfoil"
+	FinishFoil    Finish = "foil"
+	FinishEtched  Finish = "etched"
+)
+
+// Rarity is a printing's rarity, as in Card.Rarity
+type Rarity string
+
+const (
+	Common   Rarity = "common"
+	Uncommon Rarity = "uncommon"
+	Rare     Rarity = "rare"
+	Special  Rarity = "special"
+	Mythic   Rarity = "mythic"
+	Bonus    Rarity = "bonus"
+)
+
+// ImageStatus is the state of a printing's image, as in Card.ImageStatus
+type ImageStatus string
+
+const (
+	ImageStatusMissing     ImageStatus = "missing"      // no image yet
+	ImageStatusPlaceholder ImageStatus = "placeholder"  // a stand-in, such as for a card only just previewed
+	ImageStatusLowRes      ImageStatus = "lowres"       // a real image, but not yet a high resolution scan
+	ImageStatusHighResScan ImageStatus = "highres_scan" // a full resolution scan
+)
+
+// Game is a game a printing is available in, as listed in Card.Games
+type Game string
+
+const (
+	GamePaper  Game = "paper"
+	GameArena  Game = "arena"
+	GameMTGO   Game = "mtgo"
+	GameAstral Game = "astral" // the Astral cards from MicroProse's Shandalar
+	GameSega   Game = "sega"   // the Sega Dreamcast game
+)
+
+// Component is how a RelatedCard relates to the card listing it in AllParts
+type Component string
+
+const (
+	ComponentToken      Component = "token"
+	ComponentMeldPart   Component = "meld_part"
+	ComponentMeldResult Component = "meld_result"
+	ComponentComboPiece Component = "combo_piece"
+)
+
+// Language is a language code Scryfall uses for printings, as in Card.Lang
+type Language string
+
+const (
+	LanguageEnglish            Language = "en"
+	LanguageSpanish            Language = "es"
+	LanguageFrench             Language = "fr"
+	LanguageGerman             Language = "de"
+	LanguageItalian            Language = "it"
+	LanguagePortuguese         Language = "pt"
+	LanguageJapanese           Language = "ja"
+	LanguageKorean             Language = "ko"
+	LanguageRussian            Language = "ru"
+	LanguageSimplifiedChinese  Language = "zhs"
+	LanguageTraditionalChinese Language = "zht"
+	LanguageHebrew             Language = "he"
+	LanguageLatin              Language = "la"
+	LanguageAncientGreek       Language = "grc"
+	LanguageArabic             Language = "ar"
+	LanguageSanskrit           Language = "sa"
+	LanguagePhyrexian          Language = "ph"
+	LanguageQuenya             Language = "qya"
+)
+
+// Languages lists every language code Scryfall supports
+var Languages = []Language{
+	LanguageEnglish, LanguageSpanish, LanguageFrench, LanguageGerman, LanguageItalian,
+	LanguagePortuguese, LanguageJapanese, LanguageKorean, LanguageRussian,
+	LanguageSimplifiedChinese, LanguageTraditionalChinese, LanguageHebrew, LanguageLatin,
+	LanguageAncientGreek, LanguageArabic, LanguageSanskrit, LanguagePhyrexian, LanguageQuenya,
+}
+
 type Set struct {
 	//A content type for this object, always "set"
 	Object string `json:"object"`
@@ -185,16 +334,16 @@ type Card struct {
 	OracleID *string `json:"oracle_id"`
 
 	//A link to where you can begin paginating all re/prints for this card on Scryfall's API
-	PrintsSearchURI url.URL `json:"prints_search_uri"`
+	PrintsSearchURI LazyURL `json:"prints_search_uri"`
 
 	//A link to this card's rulings list on Scryfall's API
-	RulingsURI url.URL `json:"rulings_uri"`
+	RulingsURI LazyURL `json:"rulings_uri"`
 
 	//A link to this card's permapage on Scryfall's website
-	ScryfallURI url.URL `json:"scryfall_uri"`
+	ScryfallURI LazyURL `json:"scryfall_uri"`
 
 	//A link to this card object on Scryfall's API
-	URI url.URL `json:"uri"`
+	URI LazyURL `json:"uri"`
 
 	// Gameplay Fields
 	//If this card is closely related to other cards, this property will be an array with Related Card Objects
@@ -394,19 +543,19 @@ type Card struct {
 	Reprint bool `json:"reprint"`
 
 	//A link to this card's set on Scryfall's website
-	ScryfallSetURI url.URL `json:"scryfall_set_uri"`
+	ScryfallSetURI LazyURL `json:"scryfall_set_uri"`
 
 	//This card's full set name
 	SetName string `json:"set_name"`
 
 	//A link to where you can begin paginating this card's set on the Scryfall API
-	SetSearchURI url.URL `json:"set_search_uri"`
+	SetSearchURI LazyURL `json:"set_search_uri"`
 
 	//The type of set this printing is in
 	SetType string `json:"set_type"`
 
 	//A link to this card's set object on Scryfall's API
-	SetURI url.URL `json:"set_uri"`
+	SetURI LazyURL `json:"set_uri"`
 
 	//This card's set code
 	Set string `json:"set"`
@@ -437,6 +586,9 @@ type Card struct {
 
 	//Preview information
 	Preview *CardPreview `json:"preview"`
+
+	// raw is the card object as Scryfall sent it, kept only with ClientOptions.StoreRawJSON
+	raw json.RawMessage
 }
 
 type CardFace struct {
@@ -564,6 +716,162 @@ type CardPreview struct {
 	Source *string `json:"source"`
 }
 
+// A CardIdentifier references a card in a /cards/collection request.
+// Valid combinations are id, mtgo_id, multiverse_id, oracle_id, illustration_id,
+// name, name+set, and collector_number+set.
+type CardIdentifier struct {
+	ID              string `json:"id,omitempty"`
+	MTGOID          int    `json:"mtgo_id,omitempty"`
+	MultiverseID    int    `json:"multiverse_id,omitempty"`
+	OracleID        string `json:"oracle_id,omitempty"`
+	IllustrationID  string `json:"illustration_id,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Set             string `json:"set,omitempty"`
+	CollectorNumber string `json:"collector_number,omitempty"`
+}
+
+type CardSymbol struct {
+	//A content type for this object, always card_symbol
+	Object string `json:"object"`
+
+	//The plaintext symbol, often surrounded with curly braces {}
+	Symbol string `json:"symbol"`
+
+	//An alternate version of this symbol, if it is possible to write it without curly braces
+	//NULLABLE
+	LooseVariant *string `json:"loose_variant"`
+
+	//An English snippet that describes this symbol
+	English string `json:"english"`
+
+	//True if it is possible to write this symbol "backwards"
+	Transposable bool `json:"transposable"`
+
+	//True if this is a mana symbol
+	RepresentsMana bool `json:"represents_mana"`
+
+	//This symbol's mana value, which can be fractional for symbols from funny sets
+	//NULLABLE
+	ManaValue *float64 `json:"mana_value"`
+
+	//True if this symbol appears in a mana cost on any Magic card
+	AppearsInManaCosts bool `json:"appears_in_mana_costs"`
+
+	//True if this symbol is only used on funny cards or Un-cards
+	Funny bool `json:"funny"`
+
+	//An array of colors that this symbol represents
+	Colors []string `json:"colors"`
+
+	//True if the symbol is a hybrid mana symbol
+	Hybrid bool `json:"hybrid"`
+
+	//True if the symbol is a Phyrexian mana symbol
+	Phyrexian bool `json:"phyrexian"`
+
+	//An array of plaintext versions of this symbol that Gatherer uses on old cards
+	//NULLABLE
+	GathererAlternates []string `json:"gatherer_alternates"`
+
+	//A URI to an SVG image of this symbol on Scryfall's CDNs
+	//NULLABLE
+	SVGURI *url.URL `json:"svg_uri"`
+}
+
+// ManaCostResult is Scryfall's interpretation of a mana cost string from /symbology/parse-mana
+type ManaCostResult struct {
+	//A content type for this object, always mana_cost
+	Object string `json:"object"`
+
+	//The normalized cost, with correctly-ordered and wrapped mana symbols
+	Cost string `json:"cost"`
+
+	//The mana value, which can be fractional for Un-set mana symbols
+	CMC float64 `json:"cmc"`
+
+	//The colors of the given cost
+	Colors []string `json:"colors"`
+
+	//True if the cost is colorless
+	Colorless bool `json:"colorless"`
+
+	//True if the cost is monocolored
+	Monocolored bool `json:"monocolored"`
+
+	//True if the cost is multicolored
+	Multicolored bool `json:"multicolored"`
+}
+
+// A Catalog object contains an array of Magic datapoints (words, card values, etc)
+type Catalog struct {
+	//A content type for this object, always catalog
+	Object string `json:"object"`
+
+	//A link to the current catalog on Scryfall's API
+	URI url.URL `json:"uri"`
+
+	//The number of items in the data array
+	TotalValues int `json:"total_values"`
+
+	//An array of datapoints, as strings
+	Data []string `json:"data"`
+}
+
+// A Ruling is an Oracle ruling, Wizards of the Coast set release note, or
+// Scryfall note for a card. Rulings are shared by every printing of a card.
+type Ruling struct {
+	//A content type for this object, always ruling
+	Object string `json:"object"`
+
+	//The Oracle ID of the card this ruling is associated with
+	OracleID string `json:"oracle_id"`
+
+	//A computer-readable string indicating which company produced this ruling, either wotc or scryfall
+	Source string `json:"source"`
+
+	//The date when the ruling or note was published, YYYY-MM-DD
+	PublishedAt string `json:"published_at"`
+
+	//The text of the ruling
+	Comment string `json:"comment"`
+}
+
+// A BulkData object describes one of Scryfall's daily bulk data files
+type BulkData struct {
+	//A content type for this object, always bulk_data
+	Object string `json:"object"`
+
+	//A unique ID for this bulk item
+	ID string `json:"id"`
+
+	//A computer-readable string for the kind of bulk item (default_cards, oracle_cards, ...)
+	Type string `json:"type"`
+
+	//The time when this file was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+
+	//The Scryfall API URI for this file
+	URI string `json:"uri"`
+
+	//A human-readable name for this file
+	Name string `json:"name"`
+
+	//A human-readable description for this file
+	Description string `json:"description"`
+
+	//The size of this file in integer bytes
+	Size int64 `json:"size"`
+
+	//The URI that hosts this bulk file for fetching
+	DownloadURI string `json:"download_uri"`
+
+	//The MIME type of this file
+	ContentType string `json:"content_type"`
+
+	//The Content-Encoding encoding that will be used to transmit this file when you download it
+	ContentEncoding string `json:"content_encoding"`
+}
+
 // UnmarshalJSON implements custom unmarshalling for List to handle URL fields
 func (l *List) UnmarshalJSON(data []byte) error {
 	type Alias List
@@ -631,112 +939,262 @@ func (s *Set) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// UnmarshalJSON implements custom unmarshalling for Card to handle URL fields
-func (c *Card) UnmarshalJSON(data []byte) error {
-	type Alias Card
+// UnmarshalJSON implements custom unmarshalling for RelatedCard to handle URL fields
+func (r *RelatedCard) UnmarshalJSON(data []byte) error {
+	type Alias RelatedCard
 	aux := &struct {
-		PrintsSearchURI string `json:"prints_search_uri"`
-		RulingsURI      string `json:"rulings_uri"`
-		ScryfallURI     string `json:"scryfall_uri"`
-		URI             string `json:"uri"`
-		ScryfallSetURI  string `json:"scryfall_set_uri"`
-		SetSearchURI    string `json:"set_search_uri"`
-		SetURI          string `json:"set_uri"`
+		URI json.RawMessage `json:"uri"`
 		*Alias
 	}{
-		Alias: (*Alias)(c),
+		Alias: (*Alias)(r),
 	}
 
 	if err := json.Unmarshal(data, &aux); err != nil {
 		return err
 	}
 
-	var err error
-	var parsed *url.URL
-
-	if parsed, err = url.Parse(aux.PrintsSearchURI); err != nil {
+	parsed, err := parseURLField(aux.URI)
+	if err != nil {
 		return err
 	}
-	c.PrintsSearchURI = *parsed
+	if parsed != nil {
+		r.URI = *parsed
+	}
 
-	if parsed, err = url.Parse(aux.RulingsURI); err != nil {
-		return err
+	return nil
+}
+
+// UnmarshalJSON implements custom unmarshalling for CardPreview to handle URL fields
+func (p *CardPreview) UnmarshalJSON(data []byte) error {
+	type Alias CardPreview
+	aux := &struct {
+		SourceURI json.RawMessage `json:"source_uri"`
+		*Alias
+	}{
+		Alias: (*Alias)(p),
 	}
-	c.RulingsURI = *parsed
 
-	if parsed, err = url.Parse(aux.ScryfallURI); err != nil {
+	if err := json.Unmarshal(data, &aux); err != nil {
 		return err
 	}
-	c.ScryfallURI = *parsed
 
-	if parsed, err = url.Parse(aux.URI); err != nil {
+	parsed, err := parseURLField(aux.SourceURI)
+	if err != nil {
 		return err
 	}
-	c.URI = *parsed
+	p.SourceURI = parsed
+
+	return nil
+}
+
+// LazyURL is a URL kept as the string Scryfall sent, only parsed when Parse is called.
+// Card uses it for its links so decoding a page or a bulk file of cards doesn't pay
+// to parse seven URLs per card that are rarely read.
+type LazyURL struct {
+	raw string
+}
+
+// NewLazyURL wraps raw without parsing it
+func NewLazyURL(raw string) LazyURL {
+	return LazyURL{raw: raw}
+}
+
+// String returns the URL as it was given, "" for a missing one
+func (u LazyURL) String() string {
+	return u.raw
+}
+
+// Parse parses the URL; a missing one parses to an empty url.URL
+func (u LazyURL) Parse() (*url.URL, error) {
+	return url.Parse(u.raw)
+}
+
+// MarshalJSON writes the URL as a JSON string
+func (u LazyURL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.raw)
+}
 
-	if parsed, err = url.Parse(aux.ScryfallSetURI); err != nil {
+// UnmarshalJSON reads a JSON string, null, or a url.URL object as older exports wrote it
+func (u *LazyURL) UnmarshalJSON(data []byte) error {
+	// most URLs need no unescaping, so copy them straight out of the quotes
+	if len(data) >= 2 && data[0] == '"' && bytes.IndexByte(data, '\\') < 0 {
+		u.raw = string(data[1 : len(data)-1])
+		return nil
+	}
+	parsed, err := parseURLField(data)
+	if err != nil {
 		return err
 	}
-	c.ScryfallSetURI = *parsed
+	u.raw = ""
+	if parsed != nil {
+		u.raw = parsed.String()
+	}
+	return nil
+}
 
-	if parsed, err = url.Parse(aux.SetSearchURI); err != nil {
-		return err
+// parseURLField parses a URL that is either a JSON string (Scryfall's format) or a
+// url.URL object, which is how all_parts and preview were stored in the database
+// before these types had MarshalJSON. A missing or null value returns nil.
+func parseURLField(raw json.RawMessage) (*url.URL, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
 	}
-	c.SetSearchURI = *parsed
 
-	if parsed, err = url.Parse(aux.SetURI); err != nil {
-		return err
+	if raw[0] == '{' {
+		var stored url.URL
+		if err := json.Unmarshal(raw, &stored); err != nil {
+			return nil, err
+		}
+		return &stored, nil
 	}
-	c.SetURI = *parsed
 
-	return nil
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, err
+	}
+	return url.Parse(s)
 }
 
-// UnmarshalJSON implements custom unmarshalling for RelatedCard to handle URL fields
-func (r *RelatedCard) UnmarshalJSON(data []byte) error {
-	type Alias RelatedCard
+// MarshalJSON implements custom marshalling for List to handle URL fields
+func (l List) MarshalJSON() ([]byte, error) {
+	type Alias List
 	aux := &struct {
-		URI string `json:"uri"`
+		NextPage *string `json:"next_page"`
 		*Alias
 	}{
-		Alias: (*Alias)(r),
+		Alias: (*Alias)(&l),
 	}
 
-	if err := json.Unmarshal(data, &aux); err != nil {
-		return err
+	if l.NextPage != nil {
+		nextPage := l.NextPage.String()
+		aux.NextPage = &nextPage
 	}
 
-	var err error
-	var parsed *url.URL
-	if parsed, err = url.Parse(aux.URI); err != nil {
-		return err
-	}
-	r.URI = *parsed
+	return json.Marshal(aux)
+}
 
-	return nil
+// MarshalJSON implements custom marshalling for Set to handle URL fields
+func (s Set) MarshalJSON() ([]byte, error) {
+	type Alias Set
+	return json.Marshal(&struct {
+		ScryfallURI string `json:"scryfall_uri"`
+		URI         string `json:"uri"`
+		IconSVGURI  string `json:"icon_svg_uri"`
+		SearchURI   string `json:"search_uri"`
+		*Alias
+	}{
+		ScryfallURI: s.ScryfallURI.String(),
+		URI:         s.URI.String(),
+		IconSVGURI:  s.IconSVGURI.String(),
+		SearchURI:   s.SearchURI.String(),
+		Alias:       (*Alias)(&s),
+	})
 }
 
-// UnmarshalJSON implements custom unmarshalling for CardPreview to handle URL fields
-func (p *CardPreview) UnmarshalJSON(data []byte) error {
+// MarshalJSON implements custom marshalling for RelatedCard to handle URL fields
+func (r RelatedCard) MarshalJSON() ([]byte, error) {
+	type Alias RelatedCard
+	return json.Marshal(&struct {
+		URI string `json:"uri"`
+		*Alias
+	}{
+		URI:   r.URI.String(),
+		Alias: (*Alias)(&r),
+	})
+}
+
+// MarshalJSON implements custom marshalling for CardPreview to handle URL fields
+func (p CardPreview) MarshalJSON() ([]byte, error) {
 	type Alias CardPreview
 	aux := &struct {
 		SourceURI *string `json:"source_uri"`
 		*Alias
 	}{
-		Alias: (*Alias)(p),
+		Alias: (*Alias)(&p),
+	}
+
+	if p.SourceURI != nil {
+		sourceURI := p.SourceURI.String()
+		aux.SourceURI = &sourceURI
+	}
+
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements custom unmarshalling for CardSymbol to handle URL fields
+func (cs *CardSymbol) UnmarshalJSON(data []byte) error {
+	type Alias CardSymbol
+	aux := &struct {
+		SVGURI *string `json:"svg_uri"`
+		*Alias
+	}{
+		Alias: (*Alias)(cs),
 	}
 
 	if err := json.Unmarshal(data, &aux); err != nil {
 		return err
 	}
 
-	if aux.SourceURI != nil {
-		parsed, err := url.Parse(*aux.SourceURI)
+	if aux.SVGURI != nil {
+		parsed, err := url.Parse(*aux.SVGURI)
 		if err != nil {
 			return err
 		}
-		p.SourceURI = parsed
+		cs.SVGURI = parsed
+	}
+
+	return nil
+}
+
+// MarshalJSON implements custom marshalling for CardSymbol to handle URL fields
+func (cs CardSymbol) MarshalJSON() ([]byte, error) {
+	type Alias CardSymbol
+	aux := &struct {
+		SVGURI *string `json:"svg_uri"`
+		*Alias
+	}{
+		Alias: (*Alias)(&cs),
+	}
+
+	if cs.SVGURI != nil {
+		svgURI := cs.SVGURI.String()
+		aux.SVGURI = &svgURI
 	}
 
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements custom unmarshalling for Catalog to handle URL fields
+func (cat *Catalog) UnmarshalJSON(data []byte) error {
+	type Alias Catalog
+	aux := &struct {
+		URI string `json:"uri"`
+		*Alias
+	}{
+		Alias: (*Alias)(cat),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	parsed, err := url.Parse(aux.URI)
+	if err != nil {
+		return err
+	}
+	cat.URI = *parsed
+
 	return nil
 }
+
+// MarshalJSON implements custom marshalling for Catalog to handle URL fields
+func (cat Catalog) MarshalJSON() ([]byte, error) {
+	type Alias Catalog
+	return json.Marshal(&struct {
+		URI string `json:"uri"`
+		*Alias
+	}{
+		URI:   cat.URI.String(),
+		Alias: (*Alias)(&cat),
+	})
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// cardListJSON returns a List of n cards built by cardJSON
+func cardListJSON(n int, setURIs bool) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`{"object":"list","total_cards":` + fmt.Sprint(n) + `,"has_more":false,"data":[`)
+	for i := range n {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(cardJSON(i, setURIs))
+	}
+	buf.WriteString("]}")
+	return buf.Bytes()
+}
+
+// cardJSON returns card number i with every URI set. With setURIs false the
+// scryfall_set_uri, set_search_uri and set_uri fields are empty, as on cards rebuilt
+// from the database.
+func cardJSON(i int, setURIs bool) []byte {
+	id := fmt.Sprintf("00000000-0000-4000-8000-%012d", i)
+	setURI, setSearchURI, scryfallSetURI := "", "", ""
+	if setURIs {
+		setURI = "https://api.scryfall.com/sets/00000000-0000-4000-8000-0000000000f1"
+		setSearchURI = "https://api.scryfall.com/cards/search?order=set&q=e%3Aisd&unique=prints"
+		scryfallSetURI = "https://scryfall.com/sets/isd"
+	}
+	return fmt.Appendf(nil, `{"object":"card","id":%q,"oracle_id":%q,"name":"Card %d","lang":"en",
+		"layout":"normal","cmc":2,"mana_cost":"{1}{U}","type_line":"Creature — Human Wizard",
+		"oracle_text":"Flying","power":"2","toughness":"1","colors":["U"],"color_identity":["U"],
+		"keywords":["Flying"],"games":["paper","mtgo"],"finishes":["nonfoil","foil"],
+		"legalities":{"standard":"not_legal","modern":"legal","legacy":"legal","vintage":"legal"},
+		"prices":{"usd":"0.25","usd_foil":"1.10","eur":null,"tix":"0.02"},
+		"image_uris":{"small":"https://cards.scryfall.io/small/front/0/0/%[1]s.jpg","normal":"https://cards.scryfall.io/normal/front/0/0/%[1]s.jpg"},
+		"set":"isd","set_name":"Innistrad","collector_number":"%[3]d","rarity":"common",
+		"released_at":"2011-09-30","uri":"https://api.scryfall.com/cards/%[1]s",
+		"scryfall_uri":"https://scryfall.com/card/isd/%[3]d/card",
+		"rulings_uri":"https://api.scryfall.com/cards/%[1]s/rulings",
+		"prints_search_uri":"https://api.scryfall.com/cards/search?order=released&q=oracleid%%3A%[2]s&unique=prints",
+		"set_uri":%[4]q,"set_search_uri":%[5]q,"scryfall_set_uri":%[6]q}`,
+		id, "00000000-0000-4000-8000-0000000000a1", i, setURI, setSearchURI, scryfallSetURI)
+}
+
+// BenchmarkDecodeCardList decodes a 2000 card List, the size of a long search or a
+// set's worth of printings. Compare allocs/op to catch regressions in Card.UnmarshalJSON.
+func BenchmarkDecodeCardList(b *testing.B) {
+	for _, bench := range []struct {
+		name    string
+		setURIs bool
+	}{
+		{"AllURIs", true},
+		{"NoSetURIs", false},
+	} {
+		data := cardListJSON(2000, bench.setURIs)
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			for b.Loop() {
+				var list List
+				if err := json.Unmarshal(data, &list); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestCardUnmarshalURLs(t *testing.T) {
+	var list List
+	if err := json.Unmarshal(cardListJSON(2, true), &list); err != nil {
+		t.Fatal(err)
+	}
+	card := list.Data[1]
+	if got := card.URI.String(); got != "https://api.scryfall.com/cards/00000000-0000-4000-8000-000000000001" {
+		t.Errorf("URI = %q", got)
+	}
+	setSearchURI, err := card.SetSearchURI.Parse()
+	if err != nil || setSearchURI.Query().Get("q") != "e:isd" {
+		t.Errorf("SetSearchURI = %v, %v; want q=e:isd", setSearchURI, err)
+	}
+	if card.Name != "Card 1" || card.CollectorNumber != "1" || *card.Prices["usd"] != "0.25" {
+		t.Errorf("Alias fields not decoded: %+v", card)
+	}
+
+	// empty URIs clear the field rather than keeping an old value
+	card.SetURI = NewLazyURL("https://api.scryfall.com/sets/stale")
+	if err := json.Unmarshal(cardJSON(1, false), &card); err != nil {
+		t.Fatal(err)
+	}
+	if card.SetURI.String() != "" || card.ScryfallSetURI.String() != "" {
+		t.Errorf("empty set URIs decoded as %q, %q", card.SetURI.String(), card.ScryfallSetURI.String())
+	}
+}
+
+func TestLazyURLUnmarshal(t *testing.T) {
+	tests := []struct {
+		json string
+		want string
+	}{
+		{`"https://api.scryfall.com/cards/search?q=e%3Aisd"`, "https://api.scryfall.com/cards/search?q=e%3Aisd"},
+		{`"https:\/\/scryfall.com\/sets\/isd"`, "https://scryfall.com/sets/isd"},
+		{`""`, ""},
+		{`null`, ""},
+		// a url.URL object, as exports written before Card had MarshalJSON stored it
+		{`{"Scheme":"https","Host":"scryfall.com","Path":"/sets/isd"}`, "https://scryfall.com/sets/isd"},
+	}
+	for _, tt := range tests {
+		u := NewLazyURL("https://stale.example")
+		if err := json.Unmarshal([]byte(tt.json), &u); err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.json, err)
+			continue
+		}
+		if u.String() != tt.want {
+			t.Errorf("Unmarshal(%s) = %q, want %q", tt.json, u.String(), tt.want)
+		}
+		data, err := json.Marshal(u)
+		if err != nil || string(data) != fmt.Sprintf("%q", tt.want) {
+			t.Errorf("Marshal after Unmarshal(%s) = %s, %v", tt.json, data, err)
+		}
+	}
+}